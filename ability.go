@@ -0,0 +1,184 @@
+package ghec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ability is the parsed text of an action, such as "Attack 3, Range 2,
+// Target 2, Pierce 1". It holds what the text says about the action, ready to
+// feed into NewEnhancement with Options.
+type Ability struct {
+	// Type is the action type, from the first attribute.
+	Type ActionType
+	// Side is whom the action targets, when the text says so.
+	Side Side
+	// Targets is the number of targets, from a Target attribute. Without one,
+	// it is the number of hexes in an attack's area of effect, since each hex
+	// is a target, and 1 otherwise.
+	Targets int
+	// Hexes is the number of hexes in the area of effect, from an Area
+	// attribute. It is 1 without one.
+	Hexes int
+	// Lost and Persistent are whether the text has the lost or persistent
+	// icons.
+	Lost       bool
+	Persistent bool
+	// Attributes are the comma-separated parts of the text, in order.
+	Attributes []Attribute
+}
+
+// Attribute is one comma-separated part of an ability's text, such as
+// "Range 2" or "Poison".
+type Attribute struct {
+	// Name is the name of the attribute as written, such as "Range".
+	Name string
+	// Value is the number after the name, if it has one.
+	Value int
+	// HasValue is whether the attribute has a number.
+	HasValue bool
+	// Bonus is whether the number is written with a plus sign, as in
+	// "Summons Move +1".
+	Bonus bool
+}
+
+// String returns the attribute as written in ability text.
+func (a Attribute) String() string {
+	switch {
+	case !a.HasValue:
+		return a.Name
+	case a.Bonus:
+		return fmt.Sprintf("%s %+d", a.Name, a.Value)
+	default:
+		return fmt.Sprintf("%s %d", a.Name, a.Value)
+	}
+}
+
+// Is reports whether the attribute has the name, ignoring case.
+func (a Attribute) Is(name string) bool {
+	return strings.EqualFold(a.Name, name)
+}
+
+// Action words that start an ability's text and set its action type.
+var actionWords = map[string]ActionType{
+	"attack":    ActionAttack,
+	"move":      ActionMove,
+	"heal":      ActionHeal,
+	"shield":    ActionShield,
+	"retaliate": ActionRetaliate,
+	"summon":    ActionSummon,
+}
+
+// Area words that give the number of hexes in an area of effect, as in
+// "Area 3" or "AoE 3".
+var areaWords = []string{"area", "aoe"}
+
+// ParseAbility parses ability text, such as "Attack 3, Range 2, Target 2,
+// Pierce 1". An area of effect is written as "Area 3", "AoE 3", or "Area 3
+// hexes", and "Lost" and "Persistent" stand for their icons. "Self" and
+// "Allies" mark an action that targets allies, and "Enemies" one that targets
+// enemies.
+func ParseAbility(text string) (Ability, error) {
+	ability := Ability{Type: ActionOther, Targets: 1, Hexes: 1}
+	hasTarget := false
+	if strings.TrimSpace(text) == "" {
+		return Ability{}, fmt.Errorf("ability text is empty")
+	}
+	for i, part := range strings.Split(text, ",") {
+		attr, err := parseAttribute(part)
+		if err != nil {
+			return Ability{}, fmt.Errorf("%q: %w", text, err)
+		}
+		if i == 0 {
+			if a, ok := actionWords[strings.ToLower(firstWord(attr.Name))]; ok {
+				ability.Type = a
+			}
+		}
+		switch {
+		case attr.Is("target") && attr.HasValue:
+			ability.Targets = attr.Value
+			hasTarget = true
+		case isAreaWord(attr.Name) && attr.HasValue:
+			ability.Hexes = attr.Value
+		case attr.Is("lost"):
+			ability.Lost = true
+		case attr.Is("persistent"):
+			ability.Persistent = true
+		case attr.Is("self"), attr.Is("allies"):
+			ability.Side = SideAllies
+		case attr.Is("enemies"):
+			ability.Side = SideEnemies
+		}
+		ability.Attributes = append(ability.Attributes, attr)
+	}
+	if !hasTarget && ability.Type == ActionAttack {
+		ability.Targets = ability.Hexes
+	}
+	if ability.Targets < 1 || ability.Hexes < 1 {
+		return Ability{}, fmt.Errorf("%q: targets and hexes must be at least 1", text)
+	}
+	return ability, nil
+}
+
+// Options returns the enhancement options for what the ability text says.
+func (a Ability) Options() []Option {
+	return []Option{
+		OptionWithAction(a.Type),
+		OptionWithSide(a.Side),
+		OptionWithTargets(a.Targets),
+		OptionWithHexes(a.Hexes),
+		OptionLostAction(a.Lost),
+		OptionPersistentAction(a.Persistent),
+	}
+}
+
+// Attribute returns the first attribute with the name, ignoring case.
+func (a Ability) Attribute(name string) (Attribute, bool) {
+	for _, attr := range a.Attributes {
+		if attr.Is(name) {
+			return attr, true
+		}
+	}
+	return Attribute{}, false
+}
+
+// parseAttribute is a helper function that parses one comma-separated part of
+// ability text, with an optional number at the end. A number of hexes, as in
+// "Area 3 hexes", is the attribute's number.
+func parseAttribute(part string) (Attribute, error) {
+	words := strings.Fields(part)
+	if len(words) == 0 {
+		return Attribute{}, fmt.Errorf("empty attribute")
+	}
+	if n := len(words); n > 2 && (strings.EqualFold(words[n-1], "hex") || strings.EqualFold(words[n-1], "hexes")) {
+		words = words[:n-1]
+	}
+	last := words[len(words)-1]
+	value, err := strconv.Atoi(last)
+	if err != nil {
+		return Attribute{Name: strings.Join(words, " ")}, nil
+	}
+	if len(words) == 1 {
+		return Attribute{}, fmt.Errorf("number %s has no attribute", last)
+	}
+	name := strings.Join(words[:len(words)-1], " ")
+	return Attribute{Name: name, Value: value, HasValue: true, Bonus: strings.HasPrefix(last, "+")}, nil
+}
+
+// firstWord is a helper function that returns the first word of the name.
+func firstWord(name string) string {
+	word, _, _ := strings.Cut(name, " ")
+	return word
+}
+
+// isAreaWord is a helper function that reports whether the name gives the
+// hexes of an area of effect.
+func isAreaWord(name string) bool {
+	for _, word := range areaWords {
+		if strings.EqualFold(name, word) {
+			return true
+		}
+	}
+	return false
+}
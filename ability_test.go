@@ -0,0 +1,71 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestParseAbility(t *testing.T) {
+	tests := []struct {
+		text       string
+		action     ghec.ActionType
+		side       ghec.Side
+		targets    int
+		hexes      int
+		lost       bool
+		attributes int
+	}{
+		{"Attack 3, Range 2, Target 2, Pierce 1", ghec.ActionAttack, ghec.SideAny, 2, 1, false, 4},
+		{"Attack 3, Target 3", ghec.ActionAttack, ghec.SideAny, 3, 1, false, 2},
+		{"Attack 2, Area 3 hexes, Poison", ghec.ActionAttack, ghec.SideAny, 3, 3, false, 3},
+		{"attack 4, aoe 4, lost", ghec.ActionAttack, ghec.SideAny, 4, 4, true, 3},
+		{"Attack 2, Area 3, Target 1", ghec.ActionAttack, ghec.SideAny, 1, 3, false, 3},
+		{"Move 4, Jump", ghec.ActionMove, ghec.SideAny, 1, 1, false, 2},
+		{"Heal 2, Self", ghec.ActionHeal, ghec.SideAllies, 1, 1, false, 2},
+		{"Summon Rat Swarm", ghec.ActionSummon, ghec.SideAny, 1, 1, false, 1},
+		{"Loot 1", ghec.ActionOther, ghec.SideAny, 1, 1, false, 1},
+		{"Bless, Allies", ghec.ActionOther, ghec.SideAllies, 1, 1, false, 2},
+	}
+	for _, tc := range tests {
+		got, err := ghec.ParseAbility(tc.text)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.text, err)
+		}
+		if got.Type != tc.action || got.Side != tc.side || got.Targets != tc.targets || got.Hexes != tc.hexes || got.Lost != tc.lost || len(got.Attributes) != tc.attributes {
+			t.Fatalf("%q: unexpected ability %+v", tc.text, got)
+		}
+	}
+}
+
+func TestParseAbilityRejectsBadText(t *testing.T) {
+	for _, text := range []string{"", "Attack 3,", "3", "Attack 3, Target 0"} {
+		if _, err := ghec.ParseAbility(text); err == nil {
+			t.Fatalf("expected an error parsing %q", text)
+		}
+	}
+}
+
+func TestParsedAbilityPricesEnhancement(t *testing.T) {
+	for _, text := range []string{"Attack 3, Target 3", "Attack 3, Area 3"} {
+		ability, err := ghec.ParseAbility(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := append(ability.Options(), ghec.OptionWithLevel(ghec.Level3))
+		cost, err := ghec.NewEnhancement(ghec.EnhanceAttack, opts...).Cost()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != 150 {
+			t.Fatalf("%q: expected 150, got %d", text, cost)
+		}
+	}
+	ability, err := ghec.ParseAbility("Attack 3, Target 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ghec.NewEnhancement(ghec.EnhanceJump, ability.Options()...).Cost(); err == nil {
+		t.Fatal("expected jump on an attack to be rejected")
+	}
+}
@@ -0,0 +1,226 @@
+package ghec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ActionType is an enum of the kinds of ability an enhancement goes on.
+// Some base enhancements only make sense on certain kinds of ability.
+type ActionType int
+
+// Action* are constants for all the action types, exported for type safety.
+// ActionAny is the zero value, for when the action type is not known, and
+// allows any base enhancement.
+const (
+	ActionAny ActionType = iota
+	ActionAttack
+	ActionMove
+	ActionHeal
+	ActionShield
+	ActionRetaliate
+	ActionSummon
+	ActionOther
+)
+
+// ActionTypes returns all the action types, starting with ActionAny.
+func ActionTypes() []ActionType {
+	return []ActionType{ActionAny, ActionAttack, ActionMove, ActionHeal, ActionShield, ActionRetaliate, ActionSummon, ActionOther}
+}
+
+// String returns the command-line name of the action type.
+func (a ActionType) String() string {
+	switch a {
+	case ActionAny:
+		return "any"
+	case ActionAttack:
+		return "attack"
+	case ActionMove:
+		return "move"
+	case ActionHeal:
+		return "heal"
+	case ActionShield:
+		return "shield"
+	case ActionRetaliate:
+		return "retaliate"
+	case ActionSummon:
+		return "summon"
+	case ActionOther:
+		return "other"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseAction returns the action type with the given name. An empty name is
+// ActionAny.
+func ParseAction(name string) (ActionType, error) {
+	if name == "" {
+		return ActionAny, nil
+	}
+	for _, a := range ActionTypes() {
+		if a.String() == strings.ToLower(name) {
+			return a, nil
+		}
+	}
+	return ActionAny, fmt.Errorf("unknown action %q, must be one of %v", name, ActionTypes())
+}
+
+// MarshalText encodes the action type as its name, for data files.
+func (a ActionType) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes the action type from its name, for data files.
+func (a *ActionType) UnmarshalText(text []byte) error {
+	parsed, err := ParseAction(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// NextAction returns the action type after a, wrapping around to ActionAny.
+func NextAction(a ActionType) ActionType {
+	return (a + 1) % (ActionOther + 1)
+}
+
+// Side is an enum of whom an ability targets, which decides the conditions
+// it can take.
+type Side int
+
+// Side* are constants for all the sides, exported for type safety. SideAny is
+// the zero value, for when the side is not known.
+const (
+	SideAny Side = iota
+	SideEnemies
+	SideAllies
+)
+
+// Sides returns all the sides, starting with SideAny.
+func Sides() []Side {
+	return []Side{SideAny, SideEnemies, SideAllies}
+}
+
+// String returns the command-line name of the side.
+func (s Side) String() string {
+	switch s {
+	case SideAny:
+		return "any"
+	case SideEnemies:
+		return "enemies"
+	case SideAllies:
+		return "allies"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSide returns the side with the given name. An empty name is SideAny.
+func ParseSide(name string) (Side, error) {
+	if name == "" {
+		return SideAny, nil
+	}
+	for _, s := range Sides() {
+		if s.String() == strings.ToLower(name) {
+			return s, nil
+		}
+	}
+	return SideAny, fmt.Errorf("unknown side %q, must be one of %v", name, Sides())
+}
+
+// MarshalText encodes the side as its name, for data files.
+func (s Side) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes the side from its name, for data files.
+func (s *Side) UnmarshalText(text []byte) error {
+	parsed, err := ParseSide(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// NextSide returns the side after s, wrapping around to SideAny.
+func NextSide(s Side) Side {
+	return (s + 1) % (SideAllies + 1)
+}
+
+// sideOf is a helper function that returns the side the action targets.
+// Without an explicit side, attacks target enemies and heals, shields,
+// retaliates and moves target the figure itself or its allies.
+func sideOf(a ActionType, s Side) Side {
+	if s != SideAny {
+		return s
+	}
+	switch a {
+	case ActionAttack:
+		return SideEnemies
+	case ActionMove, ActionHeal, ActionShield, ActionRetaliate:
+		return SideAllies
+	default:
+		return SideAny
+	}
+}
+
+// CheckApplicable returns an error that explains why the base enhancement
+// cannot go on the action, or nil if it can.
+func CheckApplicable(be BaseEnhancement, a ActionType, s Side) error {
+	def, ok := lookup(be)
+	if !ok {
+		return fmt.Errorf("unknown base enhancement %d", be)
+	}
+	if a != ActionAny && len(def.actions) > 0 && !containsAction(def.actions, a) {
+		return fmt.Errorf("%s only goes on %s actions, not on %s actions", def.title, joinActions(def.actions), a)
+	}
+	side := sideOf(a, s)
+	switch {
+	case def.category == CategoryNegativeCondition && side == SideAllies:
+		return fmt.Errorf("%s only goes on actions that target enemies, and this action targets allies", def.title)
+	case def.category == CategoryPositiveCondition && side == SideEnemies:
+		return fmt.Errorf("%s only goes on actions that target allies, and this action targets enemies", def.title)
+	}
+	return nil
+}
+
+// containsAction is a helper function that reports whether the list has the
+// action type.
+func containsAction(list []ActionType, a ActionType) bool {
+	for _, action := range list {
+		if action == a {
+			return true
+		}
+	}
+	return false
+}
+
+// joinActions is a helper function that joins the action types for an error
+// message, such as "attack or heal".
+func joinActions(list []ActionType) string {
+	names := make([]string, len(list))
+	for i, a := range list {
+		names[i] = a.String()
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// Action sets the registry uses for the action types that each base
+// enhancement goes on. Base enhancements without a set go on any action.
+var (
+	moveActions      = []ActionType{ActionMove}
+	attackActions    = []ActionType{ActionAttack}
+	healActions      = []ActionType{ActionHeal}
+	shieldActions    = []ActionType{ActionShield}
+	retaliateActions = []ActionType{ActionRetaliate}
+	summonActions    = []ActionType{ActionSummon}
+	rangedActions    = []ActionType{ActionAttack, ActionHeal, ActionOther}
+	forcedActions    = []ActionType{ActionAttack, ActionOther}
+	teleportActions  = []ActionType{ActionMove, ActionOther}
+)
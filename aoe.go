@@ -0,0 +1,281 @@
+package ghec
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Hex is a hex on the grid, in axial coordinates. Q runs along a row and R
+// runs down the rows, so each row sits half a hex to the right of the one
+// above.
+type Hex struct {
+	Q, R int
+}
+
+// directions are the offsets to the six neighbors of a hex.
+var directions = [6]Hex{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+
+// Neighbors returns the six hexes next to the hex.
+func (h Hex) Neighbors() [6]Hex {
+	var neighbors [6]Hex
+	for i, d := range directions {
+		neighbors[i] = Hex{h.Q + d.Q, h.R + d.R}
+	}
+	return neighbors
+}
+
+// Adjacent reports whether the hexes share an edge.
+func (h Hex) Adjacent(o Hex) bool {
+	for _, n := range h.Neighbors() {
+		if n == o {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the hex as axial coordinates, such as "1,-1".
+func (h Hex) String() string {
+	return fmt.Sprintf("%d,%d", h.Q, h.R)
+}
+
+// AreaOfEffect is the pattern of an attack's area of effect on the hex grid.
+// It prices Add Attack Hex from the number of target hexes, which leaves out
+// the caster's hex.
+type AreaOfEffect struct {
+	// Targets are the hexes the attack targets.
+	Targets []Hex
+	// Caster is the caster's hex, if the pattern shows it.
+	Caster *Hex
+	// New is the hex to add, if the pattern marks one.
+	New *Hex
+}
+
+// Pattern characters for ASCII areas of effect. Other characters are errors,
+// except spaces between hexes.
+const (
+	patternTarget = 'X'
+	patternCaster = '@'
+	patternNew    = '+'
+	patternEmpty  = '.'
+)
+
+// ParseAreaOfEffect parses an area of effect from an ASCII pattern or from
+// axial coordinates.
+//
+// An ASCII pattern has a row per line, or rows separated by "/", with a
+// character per hex and a space between hexes. Each row is indented half a
+// hex, a single space, from its neighbors, so the hexes of alternate rows line
+// up. X is a target hex, @ is the caster, + is the hex to add, and . is an
+// empty hex. For example, " X X/X @ +" is a caster with three target hexes
+// and a new hex beside it.
+//
+// Axial coordinates are Q,R pairs separated by spaces or semicolons, with @
+// before the caster and + before the hex to add, such as "@0,0 1,0 0,1 +1,1".
+func ParseAreaOfEffect(pattern string) (AreaOfEffect, error) {
+	var (
+		aoe AreaOfEffect
+		err error
+	)
+	if strings.ContainsAny(pattern, "0123456789") {
+		aoe, err = parseAxial(pattern)
+	} else {
+		aoe, err = parseASCII(pattern)
+	}
+	if err != nil {
+		return AreaOfEffect{}, err
+	}
+	if len(aoe.Targets) == 0 {
+		return AreaOfEffect{}, fmt.Errorf("area of effect %q has no target hexes", pattern)
+	}
+	return aoe, nil
+}
+
+// parseAxial is a helper function that parses an area of effect from axial
+// coordinates.
+func parseAxial(pattern string) (AreaOfEffect, error) {
+	var aoe AreaOfEffect
+	fields := strings.FieldsFunc(pattern, func(r rune) bool {
+		return r == ' ' || r == ';' || r == '\n'
+	})
+	for _, field := range fields {
+		mark := field[0]
+		if mark == patternCaster || mark == patternNew {
+			field = field[1:]
+		}
+		qs, rs, ok := strings.Cut(field, ",")
+		q, qErr := strconv.Atoi(qs)
+		r, rErr := strconv.Atoi(rs)
+		if !ok || qErr != nil || rErr != nil {
+			return AreaOfEffect{}, fmt.Errorf("hex %q must be Q,R", field)
+		}
+		if err := aoe.place(mark, Hex{q, r}); err != nil {
+			return AreaOfEffect{}, err
+		}
+	}
+	return aoe, nil
+}
+
+// parseASCII is a helper function that parses an area of effect from an
+// ASCII pattern. A hex in column c of row r has the doubled coordinates
+// (c, r), so its axial coordinates are ((c - r) / 2, r). The first hex sets
+// which columns line up, so the top row may be indented or not.
+func parseASCII(pattern string) (AreaOfEffect, error) {
+	var aoe AreaOfEffect
+	rows := strings.FieldsFunc(pattern, func(r rune) bool {
+		return r == '/' || r == '\n'
+	})
+	parity := -1
+	for r, row := range rows {
+		for c, ch := range row {
+			if ch == ' ' {
+				continue
+			}
+			p := ((c-r)%2 + 2) % 2
+			if parity < 0 {
+				parity = p
+			}
+			if p != parity {
+				return AreaOfEffect{}, fmt.Errorf("%q is out of line at row %d, column %d", ch, r+1, c+1)
+			}
+			if ch == patternEmpty {
+				continue
+			}
+			if ch != patternTarget && ch != 'x' && ch != patternCaster && ch != patternNew {
+				return AreaOfEffect{}, fmt.Errorf("unknown hex %q at row %d, column %d", ch, r+1, c+1)
+			}
+			if err := aoe.place(byte(ch), Hex{(c - r - parity) / 2, r}); err != nil {
+				return AreaOfEffect{}, err
+			}
+		}
+	}
+	return aoe, nil
+}
+
+// place is a helper method that puts the hex in the area of effect as the
+// caster, the new hex, or a target, for the pattern mark.
+func (a *AreaOfEffect) place(mark byte, h Hex) error {
+	if a.occupied(h) {
+		return fmt.Errorf("hex %s appears twice", h)
+	}
+	switch mark {
+	case patternCaster:
+		if a.Caster != nil {
+			return fmt.Errorf("area of effect has two casters")
+		}
+		a.Caster = &h
+	case patternNew:
+		if a.New != nil {
+			return fmt.Errorf("area of effect has two new hexes")
+		}
+		a.New = &h
+	default:
+		a.Targets = append(a.Targets, h)
+	}
+	return nil
+}
+
+// occupied is a helper method that reports whether the hex is already in the
+// area of effect.
+func (a AreaOfEffect) occupied(h Hex) bool {
+	if (a.Caster != nil && *a.Caster == h) || (a.New != nil && *a.New == h) {
+		return true
+	}
+	return a.Has(h)
+}
+
+// Has reports whether the hex is a target hex.
+func (a AreaOfEffect) Has(h Hex) bool {
+	for _, t := range a.Targets {
+		if t == h {
+			return true
+		}
+	}
+	return false
+}
+
+// Hexes returns the number of target hexes, which leaves out the caster.
+func (a AreaOfEffect) Hexes() int {
+	return len(a.Targets)
+}
+
+// CanAdd returns an error if the hex cannot be added to the area of effect,
+// because it is already in the pattern or is not next to a target hex.
+func (a AreaOfEffect) CanAdd(h Hex) error {
+	if a.Has(h) || (a.Caster != nil && *a.Caster == h) {
+		return fmt.Errorf("hex %s is already in the area of effect", h)
+	}
+	for _, t := range a.Targets {
+		if t.Adjacent(h) {
+			return nil
+		}
+	}
+	return fmt.Errorf("hex %s is not next to a target hex", h)
+}
+
+// Add returns the area of effect with the hex added as a target hex.
+func (a AreaOfEffect) Add(h Hex) (AreaOfEffect, error) {
+	if err := a.CanAdd(h); err != nil {
+		return AreaOfEffect{}, err
+	}
+	added := a
+	added.Targets = append(append([]Hex(nil), a.Targets...), h)
+	if a.New != nil && *a.New == h {
+		added.New = nil
+	}
+	return added, nil
+}
+
+// Options returns the enhancement options for the area of effect, which set
+// the current hexes for Add Attack Hex.
+func (a AreaOfEffect) Options() []Option {
+	return []Option{OptionWithHexes(a.Hexes())}
+}
+
+// String returns the area of effect as an ASCII pattern, with rows separated
+// by newlines.
+func (a AreaOfEffect) String() string {
+	marks := map[Hex]rune{}
+	for _, t := range a.Targets {
+		marks[t] = patternTarget
+	}
+	if a.Caster != nil {
+		marks[*a.Caster] = patternCaster
+	}
+	if a.New != nil {
+		marks[*a.New] = patternNew
+	}
+	if len(marks) == 0 {
+		return ""
+	}
+	hexes := make([]Hex, 0, len(marks))
+	for h := range marks {
+		hexes = append(hexes, h)
+	}
+	sort.Slice(hexes, func(i, j int) bool {
+		return hexes[i].R < hexes[j].R
+	})
+	minR, maxR := hexes[0].R, hexes[len(hexes)-1].R
+	minC, maxC := 2*hexes[0].Q+hexes[0].R, 2*hexes[0].Q+hexes[0].R
+	for _, h := range hexes {
+		minC = min(minC, 2*h.Q+h.R)
+		maxC = max(maxC, 2*h.Q+h.R)
+	}
+	var rows []string
+	for r := minR; r <= maxR; r++ {
+		row := []rune(strings.Repeat(" ", maxC-minC+1))
+		for c := minC; c <= maxC; c++ {
+			if (c-r)%2 != 0 {
+				continue
+			}
+			row[c-minC] = patternEmpty
+			if mark, ok := marks[Hex{(c - r) / 2, r}]; ok {
+				row[c-minC] = mark
+			}
+		}
+		rows = append(rows, strings.TrimRight(string(row), " "))
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,101 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestParseAreaOfEffect(t *testing.T) {
+	tests := []struct {
+		pattern string
+		hexes   int
+		caster  bool
+		added   bool
+	}{
+		{"X X X", 3, false, false},
+		{" X X/X @ X", 4, true, false},
+		{" X X\nX @ +", 3, true, true},
+		{"@0,0 1,0 0,1 +1,1", 2, true, true},
+		{"0,0; 1,0; 2,0", 3, false, false},
+		{"X . X", 2, false, false},
+	}
+	for _, tc := range tests {
+		aoe, err := ghec.ParseAreaOfEffect(tc.pattern)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.pattern, err)
+		}
+		if aoe.Hexes() != tc.hexes || (aoe.Caster != nil) != tc.caster || (aoe.New != nil) != tc.added {
+			t.Fatalf("%q: unexpected area of effect %+v", tc.pattern, aoe)
+		}
+	}
+}
+
+func TestParseAreaOfEffectRejectsBadPatterns(t *testing.T) {
+	for _, pattern := range []string{"", "@", "XX", "X Y", "0,0 0,0", "@0,0 @1,0 2,0", "X + +", "1;2"} {
+		if _, err := ghec.ParseAreaOfEffect(pattern); err == nil {
+			t.Fatalf("expected an error parsing %q", pattern)
+		}
+	}
+}
+
+func TestAreaOfEffectAddsAdjacentHexes(t *testing.T) {
+	aoe, err := ghec.ParseAreaOfEffect(" X X/X @ .")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		hex ghec.Hex
+		ok  bool
+	}{
+		{ghec.Hex{Q: 1, R: 1}, true},
+		{ghec.Hex{Q: 0, R: 1}, false},
+		{ghec.Hex{Q: 0, R: 0}, false},
+		{ghec.Hex{Q: 2, R: 1}, false},
+	}
+	for _, tc := range tests {
+		err := aoe.CanAdd(tc.hex)
+		if tc.ok && err != nil {
+			t.Fatalf("expected to add %s: %v", tc.hex, err)
+		}
+		if !tc.ok && err == nil {
+			t.Fatalf("expected not to add %s", tc.hex)
+		}
+	}
+	added, err := aoe.Add(ghec.Hex{Q: 1, R: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.Hexes() != 4 || aoe.Hexes() != 3 {
+		t.Fatalf("expected 4 hexes after adding one to 3, got %d and %d", added.Hexes(), aoe.Hexes())
+	}
+}
+
+func TestAreaOfEffectPricesAddHex(t *testing.T) {
+	aoe, err := ghec.ParseAreaOfEffect(" X X/X @")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := append(aoe.Options(), ghec.OptionWithLevel(ghec.Level3), ghec.OptionWithPreviousEnhancements(ghec.PreviousEnhancements1))
+	cost, err := ghec.NewEnhancement(ghec.EnhanceAddAttackHex, opts...).Cost()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != 191 {
+		t.Fatalf("expected 191, got %d", cost)
+	}
+}
+
+func TestAreaOfEffectStringRoundTrips(t *testing.T) {
+	aoe, err := ghec.ParseAreaOfEffect("@0,0 1,0 0,1 -1,1 +1,1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ghec.ParseAreaOfEffect(aoe.String())
+	if err != nil {
+		t.Fatalf("%q: %v", aoe.String(), err)
+	}
+	if again.String() != aoe.String() || again.Hexes() != aoe.Hexes() {
+		t.Fatalf("expected %q, got %q", aoe.String(), again.String())
+	}
+}
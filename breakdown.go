@@ -0,0 +1,125 @@
+package ghec
+
+import "fmt"
+
+// LineItem is a labeled component of the cost of an enhancement. Surcharges
+// are positive and discounts are negative.
+type LineItem struct {
+	Label string
+	Cost  Cost
+}
+
+// Total adds up the line items.
+func Total(items []LineItem) Cost {
+	var total Cost
+	for _, item := range items {
+		total += item.Cost
+	}
+	return total
+}
+
+// Breakdown itemizes the cost of the enhancement, in the order the ruleset
+// applies each component. The base cost comes first, followed by the
+// multiple-target doubling, the lost or persistent adjustment, and the
+// Enhancer discount on the base cost. Then come the level and previous
+// enhancements surcharges, each followed by its Enhancer discount.
+// Components that do not change the cost are left out, except the base cost.
+func (e enhancement) Breakdown() ([]LineItem, error) {
+	if !Allows(e.ruleset, e.baseEnhancement) {
+		return nil, notAvailableError(e.ruleset, e.baseEnhancement)
+	}
+	if !e.slot.Accepts(e.baseEnhancement) {
+		return nil, fmt.Errorf("a %s slot cannot take %s", e.slot, Title(e.baseEnhancement))
+	}
+	if err := CheckApplicable(e.baseEnhancement, e.action, e.side); err != nil {
+		return nil, err
+	}
+	if err := checkElement(e.baseEnhancement, e.element); err != nil {
+		return nil, err
+	}
+	if e.level < 1 || e.level > 9 {
+		return nil, fmt.Errorf("level must be between 1 and 9, not %d", e.level)
+	}
+	if e.previousEnhancements < 0 {
+		return nil, fmt.Errorf("previous enhancements must be at least 0, not %d", e.previousEnhancements)
+	}
+	discount, err := e.ruleset.EnhancerDiscount(e.enhancerLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := e.baseItems(discount)
+	if err != nil {
+		return nil, err
+	}
+
+	levelCost, err := e.ruleset.LevelCost(e.level)
+	if err != nil {
+		return nil, err
+	}
+	items = appendItem(items, fmt.Sprintf("card level (%d)", e.level), levelCost)
+	levelDiscount := applicableDiscount(levelCost, discount.PerLevel*Cost(e.level-1))
+	items = appendItem(items, fmt.Sprintf("enhancer level %d, level discount", e.enhancerLevel), -levelDiscount)
+
+	previousCost, err := e.ruleset.PreviousCost(e.previousEnhancements)
+	if err != nil {
+		return nil, err
+	}
+	items = appendItem(items, fmt.Sprintf("previous enhancements (%d)", e.previousEnhancements), previousCost)
+	previousDiscount := applicableDiscount(previousCost, discount.PerPrevious*Cost(e.previousEnhancements))
+	items = appendItem(items, fmt.Sprintf("enhancer level %d, previous discount", e.enhancerLevel), -previousDiscount)
+
+	return items, nil
+}
+
+// baseItems is a helper method that itemizes the base cost of the
+// enhancement and the adjustments the ruleset makes to it.
+func (e enhancement) baseItems(discount Discount) ([]LineItem, error) {
+	var items []LineItem
+	cost, err := e.ruleset.BaseCost(e.baseEnhancement)
+	if err != nil {
+		return nil, err
+	}
+	if e.baseEnhancement == EnhanceAddAttackHex {
+		hexCost, err := e.ruleset.AddHexCost(e.hexes)
+		if err != nil {
+			return nil, err
+		}
+		label := fmt.Sprintf("%s base cost, %dg / %d current hexes", Title(e.baseEnhancement), cost, e.hexes)
+		if hexCost*Cost(e.hexes) != cost {
+			label += ", rounded down"
+		}
+		cost = hexCost
+		items = append(items, LineItem{label, cost})
+	} else {
+		items = append(items, LineItem{fmt.Sprintf("%s base cost", Title(e.baseEnhancement)), cost})
+	}
+
+	subtotal := cost
+	if e.targets > 1 && e.ruleset.DoublesForMultipleTargets(e.baseEnhancement) {
+		items = appendItem(items, "multiple targets, doubled", subtotal)
+		subtotal *= 2
+	}
+	switch {
+	case e.persistent:
+		adjusted := e.ruleset.PersistentActionCost(e.baseEnhancement, subtotal)
+		items = appendItem(items, "persistent action, tripled", adjusted-subtotal)
+		subtotal = adjusted
+	case e.lost:
+		adjusted := e.ruleset.LostActionCost(e.baseEnhancement, subtotal)
+		items = appendItem(items, "lost action, halved", adjusted-subtotal)
+		subtotal = adjusted
+	}
+	baseDiscount := applicableDiscount(subtotal, discount.Base)
+	items = appendItem(items, fmt.Sprintf("enhancer level %d, base discount", e.enhancerLevel), -baseDiscount)
+	return items, nil
+}
+
+// appendItem is a helper function that appends a line item unless it does
+// not change the cost.
+func appendItem(items []LineItem, label string, cost Cost) []LineItem {
+	if cost == 0 {
+		return items
+	}
+	return append(items, LineItem{label, cost})
+}
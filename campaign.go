@@ -0,0 +1,104 @@
+package ghec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Campaign holds the enhancement state of each class's ability cards. In
+// Gloomhaven, enhancements stay on a class's cards when its character
+// retires, so the next character of the class inherits them, and pays for
+// them as previous enhancements.
+type Campaign struct {
+	// Classes are the enhanced cards of each class that has had a character
+	// retire.
+	Classes []*ClassCards `json:"classes,omitempty"`
+}
+
+// ClassCards are the enhanced ability cards of a class.
+type ClassCards struct {
+	// Class is the character class.
+	Class string `json:"class"`
+	// Cards are the cards of the class with enhancements applied.
+	Cards []AbilityCard `json:"cards"`
+}
+
+// Cards returns the enhanced cards that the class has inherited from its
+// retired characters.
+func (c *Campaign) Cards(class string) []AbilityCard {
+	cc := c.class(class)
+	if cc == nil {
+		return nil
+	}
+	return cc.Cards
+}
+
+// Card returns a copy of the named card of the character's class as the
+// character has it, to price and apply enhancements to: their own copy if
+// they have bought an enhancement on it, or else the class's inherited card,
+// or else the card from the card database. It leaves the character
+// unchanged; the card becomes theirs when a purchase on it is recorded.
+func (c *Campaign) Card(ch *Character, db *CardDatabase, name string) (*AbilityCard, error) {
+	if ch.Retired {
+		return nil, fmt.Errorf("%s has retired", ch.Name)
+	}
+	if held := findCard(ch.Cards, name); held != nil {
+		card := held.clone()
+		return &card, nil
+	}
+	if inherited := findCard(c.Cards(ch.Class), name); inherited != nil {
+		card := inherited.clone()
+		return &card, nil
+	}
+	printed, err := db.Card(ch.Class, name)
+	if err != nil {
+		return nil, err
+	}
+	card := printed.clone()
+	return &card, nil
+}
+
+// Retire retires the character and moves the state of their cards to their
+// class, replacing the class's copies of the same cards.
+func (c *Campaign) Retire(ch *Character) error {
+	if ch.Retired {
+		return fmt.Errorf("%s has already retired", ch.Name)
+	}
+	cc := c.class(ch.Class)
+	if cc == nil {
+		cc = &ClassCards{Class: ch.Class}
+		c.Classes = append(c.Classes, cc)
+	}
+	for _, card := range ch.Cards {
+		if inherited := findCard(cc.Cards, card.Name); inherited != nil {
+			*inherited = card
+			continue
+		}
+		cc.Cards = append(cc.Cards, card)
+	}
+	ch.Cards = nil
+	ch.Retired = true
+	return nil
+}
+
+// class is a helper method that returns the cards of the class, ignoring
+// case, or nil if it has none.
+func (c *Campaign) class(class string) *ClassCards {
+	for _, cc := range c.Classes {
+		if strings.EqualFold(cc.Class, class) {
+			return cc
+		}
+	}
+	return nil
+}
+
+// findCard is a helper function that returns the named card, ignoring case,
+// or nil if it is not among the cards.
+func findCard(cards []AbilityCard, name string) *AbilityCard {
+	for i := range cards {
+		if strings.EqualFold(cards[i].Name, name) {
+			return &cards[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestRetiredEnhancementsStayWithTheClass(t *testing.T) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := ghec.NewLedger()
+	first, err := l.Create("Grok", "Brute", 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost, err := l.EnhanceSlot("Grok", db, "Trample", ghec.HalfTop, 0, ghec.EnhanceAttack); err != nil || cost != 50 {
+		t.Fatalf("expected the first enhancement to cost 50, got %d, %v", cost, err)
+	}
+	if err := l.Retire("Grok"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Retire("Grok"); err == nil {
+		t.Fatal("expected an error for retiring twice")
+	}
+	if _, err := l.Card(first, db, "Trample"); err == nil {
+		t.Fatal("expected an error for a retired character's card")
+	}
+
+	next, err := l.Create("Brak", "Brute", 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	card, err := l.Card(next, db, "trample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !card.Top.Slots[0].Filled {
+		t.Fatal("expected the next Brute to inherit the enhanced slot")
+	}
+	// Pierce costs 30, plus 75 for the inherited enhancement.
+	if cost, err := next.EnhanceSlot(card, ghec.HalfTop, 1, ghec.EnhancePierce); err != nil || cost != 105 {
+		t.Fatalf("expected the inherited enhancement to cost 105, got %d, %v", cost, err)
+	}
+
+	printed, err := db.Card("Brute", "Trample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if printed.Top.Slots[0].Filled {
+		t.Fatal("expected the card database to stay unenhanced")
+	}
+	if inherited := l.Cards("brute"); len(inherited) != 1 || inherited[0].Top.Slots[1].Filled {
+		t.Fatal("expected the class to keep its cards until the next retirement")
+	}
+}
+
+func TestEnhanceSlotCannotOverspend(t *testing.T) {
+	c := &ghec.Character{Name: "Grok", Class: "Brute", Gold: 40}
+	card := newCard()
+	if _, err := c.EnhanceSlot(&card, ghec.HalfTop, 0, ghec.EnhanceAttack); err == nil {
+		t.Fatal("expected an error for an enhancement the character cannot afford")
+	}
+	if card.Top.Slots[0].Filled || c.Gold != 40 {
+		t.Fatal("expected the card and the character unchanged")
+	}
+}
+
+func TestCardIsKeptOnlyWhenBought(t *testing.T) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := ghec.NewLedger()
+	c, err := l.Create("Grok", "Brute", 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	card, err := l.Card(c, db, "Trample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := card.Price(ghec.HalfTop, 0, ghec.EnhanceAttack); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.EnhanceSlot("Grok", db, "Trample", ghec.HalfTop, 0, ghec.EnhanceAttack); err == nil {
+		t.Fatal("expected an error for an enhancement the character cannot afford")
+	}
+	if len(c.Cards) != 0 {
+		t.Fatalf("expected no cards before a purchase, got %d", len(c.Cards))
+	}
+	if err := l.Retire("Grok"); err != nil {
+		t.Fatal(err)
+	}
+	if inherited := l.Cards("Brute"); len(inherited) != 0 {
+		t.Fatalf("expected the class to inherit no unbought cards, got %d", len(inherited))
+	}
+}
@@ -0,0 +1,278 @@
+package ghec
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AbilityCard is an ability card with its enhancement slots. It remembers the
+// enhancements applied to it, so the next price counts them as previous
+// enhancements.
+type AbilityCard struct {
+	// Class is the character class the ability card belongs to.
+	Class string `json:"class,omitempty"`
+	// Name is the name of the ability card.
+	Name string `json:"name"`
+	// Number is the number printed on the ability card, which other apps
+	// use to identify it. Zero means it is unknown.
+	Number int `json:"number,omitempty"`
+	// Level is the level of the ability card, which affects the enhancement
+	// cost.
+	Level Level `json:"level"`
+	// Top and Bottom are the actions on the two halves of the card.
+	Top    Action `json:"top"`
+	Bottom Action `json:"bottom"`
+}
+
+// Action is the action on one half of an ability card.
+type Action struct {
+	// Text is the printed text of the action, with its abilities separated
+	// by commas, such as "Attack 3, Pierce 2".
+	Text string `json:"text,omitempty"`
+	// Type is the action type, which limits the base enhancements it takes.
+	Type ActionType `json:"type"`
+	// Side is whom the action targets, which decides the conditions it takes.
+	Side Side `json:"side,omitempty"`
+	// Targets is the number of targets of the action. Zero means one.
+	Targets int `json:"targets,omitempty"`
+	// Hexes is the number of hexes in the action's area of effect. Zero
+	// means one.
+	Hexes int `json:"hexes,omitempty"`
+	// Lost is whether the action has the lost icon.
+	Lost bool `json:"lost,omitempty"`
+	// Persistent is whether the action has the persistent icon.
+	Persistent bool `json:"persistent,omitempty"`
+	// Slots are the enhancement slots on the action, in the order they are
+	// printed.
+	Slots []Slot `json:"slots,omitempty"`
+}
+
+// Slot is an enhancement slot on an action.
+type Slot struct {
+	// Type is the slot type, which limits the base enhancements it takes.
+	Type SlotType
+	// Ability is the position of the slot: the index of the ability in the
+	// action's text that the slot sits beside.
+	Ability int
+	// Filled is whether an enhancement has been applied to the slot.
+	Filled bool
+	// Enhancement is the base enhancement applied to the slot, if it is
+	// filled.
+	Enhancement BaseEnhancement
+	// Element is the element an element enhancement in the slot infuses, if
+	// it was chosen.
+	Element Element
+}
+
+// slotJSON is the data file form of a slot. An empty slot has no
+// enhancement, since the zero base enhancement is a real one.
+type slotJSON struct {
+	Type        SlotType         `json:"type"`
+	Ability     int              `json:"ability"`
+	Enhancement *BaseEnhancement `json:"enhancement,omitempty"`
+	Element     Element          `json:"element,omitempty"`
+}
+
+// MarshalJSON encodes the slot in its data file form.
+func (s Slot) MarshalJSON() ([]byte, error) {
+	data := slotJSON{Type: s.Type, Ability: s.Ability, Element: s.Element}
+	if s.Filled {
+		data.Enhancement = &s.Enhancement
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON decodes the slot from its data file form.
+func (s *Slot) UnmarshalJSON(b []byte) error {
+	var data slotJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	*s = Slot{Type: data.Type, Ability: data.Ability, Element: data.Element}
+	if data.Enhancement != nil {
+		s.Filled = true
+		s.Enhancement = *data.Enhancement
+	}
+	return nil
+}
+
+// Half is an enum of the halves of an ability card.
+type Half int
+
+// Half* are constants for the halves of an ability card.
+const (
+	HalfTop Half = iota
+	HalfBottom
+)
+
+// String returns the name of the half.
+func (h Half) String() string {
+	switch h {
+	case HalfTop:
+		return "top"
+	case HalfBottom:
+		return "bottom"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseHalf returns the half of an ability card with the name.
+func ParseHalf(name string) (Half, error) {
+	for _, h := range []Half{HalfTop, HalfBottom} {
+		if strings.EqualFold(name, h.String()) {
+			return h, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown half %q, must be top or bottom", name)
+}
+
+// Action returns the action on the half of the card.
+func (c *AbilityCard) Action(h Half) (*Action, error) {
+	switch h {
+	case HalfTop:
+		return &c.Top, nil
+	case HalfBottom:
+		return &c.Bottom, nil
+	default:
+		return nil, fmt.Errorf("unknown half %d", h)
+	}
+}
+
+// Previous returns the number of previous enhancements for an enhancement on
+// the half of the card. The ruleset's scope decides whether it counts the
+// enhancements applied to the whole card or only to the same action.
+func (c AbilityCard) Previous(r Ruleset, h Half) PreviousEnhancements {
+	if r.PreviousScope() == ScopeAction {
+		a, err := c.Action(h)
+		if err != nil {
+			return 0
+		}
+		return PreviousEnhancements(a.filled())
+	}
+	return PreviousEnhancements(c.Top.filled() + c.Bottom.filled())
+}
+
+// Enhancement returns the enhancement of the base enhancement in the slot,
+// with the card's level, the action's modifiers and the slot type. The options
+// apply next, for settings the card does not hold, such as the ruleset. The
+// previous enhancements always come from the card, counted in the ruleset's
+// scope.
+func (c AbilityCard) Enhancement(h Half, slot int, be BaseEnhancement, options ...Option) (*enhancement, error) {
+	a, s, err := c.slot(h, slot)
+	if err != nil {
+		return nil, err
+	}
+	if s.Filled {
+		return nil, fmt.Errorf("slot %d on the %s action of %s already has %s", slot, h, c.Name, Title(s.Enhancement))
+	}
+	opts := []Option{
+		OptionWithLevel(c.Level),
+		OptionWithTargets(max(a.Targets, 1)),
+		OptionWithHexes(max(a.Hexes, 1)),
+		OptionLostAction(a.Lost),
+		OptionPersistentAction(a.Persistent),
+		OptionWithSlot(s.Type),
+		OptionWithAction(a.Type),
+		OptionWithSide(a.Side),
+	}
+	e := NewEnhancement(be, append(opts, options...)...)
+	e.previousEnhancements = c.Previous(e.ruleset, h)
+	return e, nil
+}
+
+// Price returns the cost of the base enhancement in the slot, without
+// applying it.
+func (c AbilityCard) Price(h Half, slot int, be BaseEnhancement, options ...Option) (Cost, error) {
+	e, err := c.Enhancement(h, slot, be, options...)
+	if err != nil {
+		return 0, err
+	}
+	return e.Cost()
+}
+
+// Apply applies the base enhancement to the slot and returns what it cost.
+// It leaves the card unchanged if the enhancement cannot be priced. Adding a
+// target or an attack hex also updates the action's number of targets or
+// hexes, and the slot keeps the element from OptionWithElement.
+func (c *AbilityCard) Apply(h Half, slot int, be BaseEnhancement, options ...Option) (Cost, error) {
+	e, err := c.Enhancement(h, slot, be, options...)
+	if err != nil {
+		return 0, err
+	}
+	cost, err := e.Cost()
+	if err != nil {
+		return 0, err
+	}
+	a, s, err := c.slot(h, slot)
+	if err != nil {
+		return 0, err
+	}
+	s.Filled = true
+	s.Enhancement = be
+	s.Element = e.element
+	switch be {
+	case EnhanceTarget:
+		a.Targets = max(a.Targets, 1) + 1
+	case EnhanceAddAttackHex:
+		a.Hexes = max(a.Hexes, 1) + 1
+	}
+	return cost, nil
+}
+
+// Rendered returns the text of the action with the enhancements in its
+// filled slots applied, in slot order.
+func (a Action) Rendered() (string, error) {
+	ability, err := ParseAbility(a.Text)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range a.Slots {
+		if !s.Filled {
+			continue
+		}
+		if s.Element != ElementNone {
+			ability, err = ability.EnhanceElement(s.Element)
+		} else {
+			ability, err = ability.Enhance(s.Enhancement)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return ability.String(), nil
+}
+
+// clone is a helper method that returns a copy of the card that shares no
+// slots with it, so applying enhancements to one leaves the other unchanged.
+func (c AbilityCard) clone() AbilityCard {
+	c.Top.Slots = append([]Slot(nil), c.Top.Slots...)
+	c.Bottom.Slots = append([]Slot(nil), c.Bottom.Slots...)
+	return c
+}
+
+// slot is a helper method that returns the action and the slot at the index
+// on the half of the card.
+func (c *AbilityCard) slot(h Half, slot int) (*Action, *Slot, error) {
+	a, err := c.Action(h)
+	if err != nil {
+		return nil, nil, err
+	}
+	if slot < 0 || slot >= len(a.Slots) {
+		return nil, nil, fmt.Errorf("%s has no slot %d on its %s action", c.Name, slot, h)
+	}
+	return a, &a.Slots[slot], nil
+}
+
+// filled is a helper method that returns the number of filled slots on the
+// action.
+func (a Action) filled() int {
+	n := 0
+	for _, s := range a.Slots {
+		if s.Filled {
+			n++
+		}
+	}
+	return n
+}
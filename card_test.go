@@ -0,0 +1,148 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+// newCard returns a level 2 card with an attack on top and a move on the
+// bottom.
+func newCard() ghec.AbilityCard {
+	return ghec.AbilityCard{
+		Name:  "Test Card",
+		Level: ghec.Level2,
+		Top: ghec.Action{
+			Type: ghec.ActionAttack,
+			Slots: []ghec.Slot{
+				{Type: ghec.SlotSquare},
+				{Type: ghec.SlotDiamond},
+			},
+		},
+		Bottom: ghec.Action{
+			Type:  ghec.ActionMove,
+			Slots: []ghec.Slot{{Type: ghec.SlotCircle}},
+		},
+	}
+}
+
+func TestAbilityCardCountsAppliedEnhancements(t *testing.T) {
+	card := newCard()
+	steps := []struct {
+		half     ghec.Half
+		slot     int
+		base     ghec.BaseEnhancement
+		expected ghec.Cost
+	}{
+		{ghec.HalfTop, 0, ghec.EnhanceAttack, 75},
+		{ghec.HalfTop, 1, ghec.EnhancePoison, 175},
+		{ghec.HalfBottom, 0, ghec.EnhanceJump, 225},
+	}
+	for i, step := range steps {
+		if got := card.Previous(ghec.Gloomhaven1e{}, ghec.HalfTop); got != ghec.PreviousEnhancements(i) {
+			t.Fatalf("expected %d previous enhancements, got %d", i, got)
+		}
+		cost, err := card.Apply(step.half, step.slot, step.base)
+		if err != nil {
+			t.Fatalf("applying %s: %v", ghec.Title(step.base), err)
+		}
+		if cost != step.expected {
+			t.Fatalf("expected %s to cost %d, got %d", ghec.Title(step.base), step.expected, cost)
+		}
+	}
+}
+
+func TestAbilityCardRejectsIllegalEnhancements(t *testing.T) {
+	card := newCard()
+	if _, err := card.Apply(ghec.HalfTop, 0, ghec.EnhanceAttack); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		half ghec.Half
+		slot int
+		base ghec.BaseEnhancement
+	}{
+		{"filled slot", ghec.HalfTop, 0, ghec.EnhanceAttack},
+		{"missing slot", ghec.HalfBottom, 1, ghec.EnhanceMove},
+		{"slot type", ghec.HalfTop, 1, ghec.EnhanceBless},
+		{"action type", ghec.HalfBottom, 0, ghec.EnhancePierce},
+	}
+	for _, tc := range tests {
+		if _, err := card.Apply(tc.half, tc.slot, tc.base); err == nil {
+			t.Fatalf("%s: expected an error applying %s", tc.name, ghec.Title(tc.base))
+		}
+	}
+	if got := card.Previous(ghec.Gloomhaven1e{}, ghec.HalfTop); got != ghec.PreviousEnhancements1 {
+		t.Fatalf("expected rejected enhancements to leave 1 previous enhancement, got %d", got)
+	}
+}
+
+func TestAbilityCardAddsTargetsAndHexes(t *testing.T) {
+	card := ghec.AbilityCard{
+		Name:  "Test Card",
+		Level: ghec.Level1,
+		Top: ghec.Action{
+			Type:  ghec.ActionAttack,
+			Hexes: 3,
+			Slots: []ghec.Slot{{Type: ghec.SlotHex}, {Type: ghec.SlotSquare}},
+		},
+	}
+	if _, err := card.Apply(ghec.HalfTop, 0, ghec.EnhanceAddAttackHex); err != nil {
+		t.Fatal(err)
+	}
+	if card.Top.Hexes != 4 {
+		t.Fatalf("expected 4 hexes after adding one, got %d", card.Top.Hexes)
+	}
+	if _, err := card.Apply(ghec.HalfTop, 1, ghec.EnhanceTarget); err != nil {
+		t.Fatal(err)
+	}
+	if card.Top.Targets != 2 {
+		t.Fatalf("expected 2 targets after adding one, got %d", card.Top.Targets)
+	}
+}
+
+func TestAbilityCardPreviousScope(t *testing.T) {
+	card := newCard()
+	if _, err := card.Apply(ghec.HalfTop, 0, ghec.EnhanceAttack); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ruleset  ghec.Ruleset
+		half     ghec.Half
+		expected ghec.PreviousEnhancements
+	}{
+		{ghec.Gloomhaven1e{}, ghec.HalfTop, 1},
+		{ghec.Gloomhaven1e{}, ghec.HalfBottom, 1},
+		{ghec.Frosthaven{}, ghec.HalfTop, 1},
+		{ghec.Frosthaven{}, ghec.HalfBottom, 0},
+		{ghec.Gloomhaven2e{}, ghec.HalfBottom, 0},
+		{ghec.JawsOfTheLion{}, ghec.HalfBottom, 1},
+	}
+	for _, tc := range tests {
+		if got := card.Previous(tc.ruleset, tc.half); got != tc.expected {
+			t.Fatalf("expected %s to count %d previous enhancements on the %s action, got %d", tc.ruleset.Name(), tc.expected, tc.half, got)
+		}
+	}
+}
+
+func TestAbilityCardHasNoPreviousCap(t *testing.T) {
+	slots := make([]ghec.Slot, 6)
+	for i := range slots {
+		slots[i] = ghec.Slot{Type: ghec.SlotSquare}
+	}
+	card := ghec.AbilityCard{
+		Name:  "Test Card",
+		Level: ghec.Level1,
+		Top:   ghec.Action{Type: ghec.ActionAttack, Slots: slots},
+	}
+	for i := range slots {
+		cost, err := card.Apply(ghec.HalfTop, i, ghec.EnhanceAttack, ghec.OptionWithRuleset(ghec.Frosthaven{}))
+		if err != nil {
+			t.Fatalf("applying enhancement %d: %v", i+1, err)
+		}
+		if expected := ghec.Cost(50 + 75*i); cost != expected {
+			t.Fatalf("expected enhancement %d to cost %d, got %d", i+1, expected, cost)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package ghec
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// cardData holds the ability cards that ship with ghec, one data file per
+// class, in a directory per game.
+//
+//go:embed carddata
+var cardData embed.FS
+
+// classFile is the data file form of a class's ability cards.
+type classFile struct {
+	Class string        `json:"class"`
+	Cards []AbilityCard `json:"cards"`
+}
+
+// CardDatabase holds the ability cards of the classes it has loaded.
+type CardDatabase struct {
+	cards []AbilityCard
+}
+
+// NewCardDatabase returns an empty card database.
+func NewCardDatabase() *CardDatabase {
+	return &CardDatabase{}
+}
+
+// DefaultCardDatabase returns a card database with the ability cards that ship
+// with ghec.
+func DefaultCardDatabase() (*CardDatabase, error) {
+	db := NewCardDatabase()
+	if err := db.LoadFS(cardData, "carddata"); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// Load adds the ability cards in a class data file. A card with the same class
+// and name as one already loaded replaces it, so a data file can correct the
+// shipped cards.
+func (db *CardDatabase) Load(r io.Reader) error {
+	var file classFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return err
+	}
+	if file.Class == "" {
+		return fmt.Errorf("class data file has no class")
+	}
+	for _, card := range file.Cards {
+		card.Class = file.Class
+		if err := card.validate(); err != nil {
+			return err
+		}
+		db.add(card)
+	}
+	return nil
+}
+
+// LoadFS adds the ability cards in every .json file under the root directory
+// of the file system.
+func (db *CardDatabase) LoadFS(fsys fs.FS, root string) error {
+	return fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(name) != ".json" {
+			return nil
+		}
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := db.Load(f); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// LoadPath adds the ability cards in a class data file, or in every .json file
+// under a directory, for classes and expansions that do not ship with ghec.
+func (db *CardDatabase) LoadPath(name string) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return db.LoadFS(os.DirFS(name), ".")
+	}
+	return db.LoadFS(os.DirFS(filepath.Dir(name)), filepath.Base(name))
+}
+
+// Cards returns all the ability cards, sorted by class, level, and name.
+func (db *CardDatabase) Cards() []AbilityCard {
+	return db.Search("", "")
+}
+
+// Classes returns the names of the classes with ability cards, sorted.
+func (db *CardDatabase) Classes() []string {
+	seen := map[string]bool{}
+	var classes []string
+	for _, card := range db.cards {
+		if !seen[card.Class] {
+			seen[card.Class] = true
+			classes = append(classes, card.Class)
+		}
+	}
+	sort.Strings(classes)
+	return classes
+}
+
+// Search returns the ability cards of the class whose names contain the name,
+// sorted by class, level, and name. Both are case-insensitive, and an empty
+// class or name matches every card.
+func (db *CardDatabase) Search(class, name string) []AbilityCard {
+	var found []AbilityCard
+	for _, card := range db.cards {
+		if class != "" && !strings.EqualFold(card.Class, class) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(card.Name), strings.ToLower(name)) {
+			continue
+		}
+		found = append(found, card)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Class != found[j].Class {
+			return found[i].Class < found[j].Class
+		}
+		if found[i].Level != found[j].Level {
+			return found[i].Level < found[j].Level
+		}
+		return found[i].Name < found[j].Name
+	})
+	return found
+}
+
+// Card returns the ability card of the class with the name. Both are
+// case-insensitive.
+func (db *CardDatabase) Card(class, name string) (AbilityCard, error) {
+	for _, card := range db.cards {
+		if strings.EqualFold(card.Class, class) && strings.EqualFold(card.Name, name) {
+			return card, nil
+		}
+	}
+	return AbilityCard{}, fmt.Errorf("no %s card named %q", class, name)
+}
+
+// CardNumber returns the ability card of the class with the printed number.
+// The class is case-insensitive.
+func (db *CardDatabase) CardNumber(class string, number int) (AbilityCard, error) {
+	for _, card := range db.cards {
+		if number > 0 && card.Number == number && strings.EqualFold(card.Class, class) {
+			return card, nil
+		}
+	}
+	return AbilityCard{}, fmt.Errorf("no %s card numbered %d in the card database", class, number)
+}
+
+// add is a helper method that adds the card, replacing a card with the same
+// class and name.
+func (db *CardDatabase) add(card AbilityCard) {
+	for i, c := range db.cards {
+		if strings.EqualFold(c.Class, card.Class) && strings.EqualFold(c.Name, card.Name) {
+			db.cards[i] = card
+			return
+		}
+	}
+	db.cards = append(db.cards, card)
+}
+
+// validate is a helper method that checks the data file fields of the card.
+func (c AbilityCard) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%s card has no name", c.Class)
+	}
+	if c.Level < Level1 || c.Level > Level9 {
+		return fmt.Errorf("%s has level %d, which must be between 1 and 9", c.Name, c.Level)
+	}
+	for _, h := range []Half{HalfTop, HalfBottom} {
+		a, _ := c.Action(h)
+		for i, s := range a.Slots {
+			if s.Ability < 0 {
+				return fmt.Errorf("%s has slot %d on its %s action at ability %d", c.Name, i, h, s.Ability)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package ghec_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestDefaultCardDatabaseHasStartingClasses(t *testing.T) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, class := range []string{"Brute", "Cragheart", "Mindthief", "Scoundrel", "Spellweaver", "Tinkerer"} {
+		if len(db.Search(class, "")) == 0 {
+			t.Fatalf("expected cards for %s", class)
+		}
+	}
+	card, err := db.Card("brute", "trample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Class != "Brute" || card.Level != ghec.Level1 || len(card.Top.Slots) == 0 {
+		t.Fatalf("unexpected card %+v", card)
+	}
+}
+
+func TestShippedSlotsSitBesideAnAbility(t *testing.T) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, card := range db.Cards() {
+		for _, a := range []ghec.Action{card.Top, card.Bottom} {
+			abilities := len(strings.Split(a.Text, ","))
+			for _, s := range a.Slots {
+				if s.Ability >= abilities {
+					t.Fatalf("%s has a slot beside ability %d of %q", card.Name, s.Ability, a.Text)
+				}
+			}
+		}
+	}
+}
+
+func TestCardDatabaseLoadsExtraFiles(t *testing.T) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	data := `{"class": "Brute", "cards": [
+		{"name": "Trample", "level": 1, "top": {"text": "Attack 4", "type": "attack"}, "bottom": {"type": "move"}},
+		{"name": "Fatal Advance", "level": 2, "top": {"text": "Attack 4", "type": "attack", "slots": [{"type": "square", "ability": 0}]}, "bottom": {"type": "move"}}
+	]}`
+	if err := os.WriteFile(filepath.Join(dir, "brute.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	before := len(db.Search("brute", ""))
+	if err := db.LoadPath(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(db.Search("brute", "")); got != before+1 {
+		t.Fatalf("expected %d Brute cards, got %d", before+1, got)
+	}
+	card, err := db.Card("Brute", "Trample")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Top.Text != "Attack 4" {
+		t.Fatalf("expected the loaded card to replace the shipped one, got %q", card.Top.Text)
+	}
+}
+
+func TestCardDatabaseRejectsBadFiles(t *testing.T) {
+	tests := []string{
+		`{"cards": [{"name": "Nameless Class", "level": 1}]}`,
+		`{"class": "Brute", "cards": [{"name": "Level Zero", "level": 0}]}`,
+		`{"class": "Brute", "cards": [{"name": "Bad Slot", "level": 1, "top": {"slots": [{"type": "oval"}]}}]}`,
+	}
+	for _, data := range tests {
+		if err := ghec.NewCardDatabase().Load(strings.NewReader(data)); err == nil {
+			t.Fatalf("expected an error loading %s", data)
+		}
+	}
+}
+
+func TestShippedAreaAttacksHaveMultipleTargets(t *testing.T) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	card, err := db.Card("brute", "sweeping blow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cost, err := card.Price(ghec.HalfTop, 0, ghec.EnhanceAttack)
+	if err != nil || cost != 100 {
+		t.Fatalf("expected 100 for attack on Sweeping Blow, got %d, %v", cost, err)
+	}
+	for _, card := range db.Cards() {
+		for _, a := range []ghec.Action{card.Top, card.Bottom} {
+			if a.Type == ghec.ActionAttack && a.Hexes > 1 && a.Targets < 2 {
+				t.Fatalf("%s has a %d-hex attack with %d targets", card.Name, a.Hexes, a.Targets)
+			}
+		}
+	}
+}
@@ -0,0 +1,429 @@
+package ghec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Character is a player character, with the gold they have and the
+// enhancements they have bought with it.
+type Character struct {
+	// Name is the name of the character, which is unique in its ledger.
+	Name string `json:"name"`
+	// Class is the character class.
+	Class string `json:"class"`
+	// Level is the character's level, which is the highest level of ability
+	// card they can take, if it is known.
+	Level int `json:"level,omitempty"`
+	// Gold is the gold the character has.
+	Gold int `json:"gold"`
+	// Enhancements are the enhancements the character has bought, in the
+	// order they were bought.
+	Enhancements []Purchase `json:"enhancements,omitempty"`
+	// Cards are the character's ability cards that have been priced or
+	// enhanced, with the enhancements applied to their slots.
+	Cards []AbilityCard `json:"cards,omitempty"`
+	// Retired is whether the character has retired, leaving their cards to
+	// their class.
+	Retired bool `json:"retired,omitempty"`
+}
+
+// Purchase is an enhancement that a character bought.
+type Purchase struct {
+	// Enhancement is the base enhancement that was applied.
+	Enhancement BaseEnhancement `json:"enhancement"`
+	// Card is the name of the ability card it was applied to, if it was given.
+	Card string `json:"card,omitempty"`
+	// Level is the level of the ability card, if it is known.
+	Level Level `json:"level,omitempty"`
+	// Cost is the gold the enhancement cost.
+	Cost Cost `json:"cost"`
+	// Game is the name of the ruleset that priced the enhancement.
+	Game string `json:"game,omitempty"`
+	// Source is where the enhancement was recorded, for one that was
+	// imported instead of bought here.
+	Source string `json:"source,omitempty"`
+}
+
+// AddGold adds gold to the character.
+func (c *Character) AddGold(gold int) error {
+	if gold < 0 {
+		return fmt.Errorf("gold to add must be at least 0, not %d", gold)
+	}
+	c.Gold += gold
+	return nil
+}
+
+// SpendGold takes gold from the character. It returns an error if the
+// character does not have enough.
+func (c *Character) SpendGold(gold int) error {
+	if gold < 0 {
+		return fmt.Errorf("gold to spend must be at least 0, not %d", gold)
+	}
+	if gold > c.Gold {
+		return fmt.Errorf("%s has %d gold, not enough to spend %d", c.Name, c.Gold, gold)
+	}
+	c.Gold -= gold
+	return nil
+}
+
+// Enhance prices the base enhancement with the options, takes the cost from
+// the character's gold, and records the purchase on the named card. It leaves
+// the character unchanged if the enhancement cannot be priced or the
+// character cannot afford it.
+func (c *Character) Enhance(be BaseEnhancement, card string, options ...Option) (Cost, error) {
+	e := NewEnhancement(be, options...)
+	cost, err := e.Cost()
+	if err != nil {
+		return 0, err
+	}
+	if int(cost) > c.Gold {
+		return 0, fmt.Errorf("%s costs %d gold, but %s has %d", Title(be), cost, c.Name, c.Gold)
+	}
+	c.Gold -= int(cost)
+	c.Enhancements = append(c.Enhancements, Purchase{Enhancement: be, Card: card, Level: e.level, Cost: cost, Game: e.ruleset.Name()})
+	return cost, nil
+}
+
+// EnhanceSlot prices the base enhancement in the slot of the card, takes the
+// cost from the character's gold, applies it to the card, and records the
+// purchase. The card's level, action, slot type, and previous enhancements
+// apply before the options, as in AbilityCard.Enhancement. It leaves the
+// character and the card unchanged if the enhancement cannot be priced or the
+// character cannot afford it.
+func (c *Character) EnhanceSlot(card *AbilityCard, h Half, slot int, be BaseEnhancement, options ...Option) (Cost, error) {
+	e, err := card.Enhancement(h, slot, be, options...)
+	if err != nil {
+		return 0, err
+	}
+	cost, err := e.Cost()
+	if err != nil {
+		return 0, err
+	}
+	if int(cost) > c.Gold {
+		return 0, fmt.Errorf("%s costs %d gold, but %s has %d", Title(be), cost, c.Name, c.Gold)
+	}
+	if _, err := card.Apply(h, slot, be, options...); err != nil {
+		return 0, err
+	}
+	c.Gold -= int(cost)
+	c.Enhancements = append(c.Enhancements, Purchase{Enhancement: be, Card: card.Name, Level: card.Level, Cost: cost, Game: e.ruleset.Name()})
+	return cost, nil
+}
+
+// keep is a helper method that stores a copy of the card among the
+// character's cards, replacing their copy of it if they have one.
+func (c *Character) keep(card AbilityCard) {
+	card = card.clone()
+	if held := findCard(c.Cards, card.Name); held != nil {
+		*held = card
+		return
+	}
+	c.Cards = append(c.Cards, card)
+}
+
+// Ledger holds the characters of a group and the campaign's class cards, so
+// their gold and enhancements last between runs. It keeps every change as an
+// event, and the characters and the campaign are the replay of the events in
+// effect, so a change can be undone. Make changes with the ledger's methods,
+// which record them; changes made to a character directly are not saved.
+type Ledger struct {
+	// Events are the changes in the order they were made.
+	Events []Event `json:"events"`
+	// Characters are the characters in the order they were created.
+	Characters []*Character `json:"-"`
+	// Campaign holds the cards that classes inherit from retired characters.
+	Campaign `json:"-"`
+}
+
+// NewLedger returns an empty ledger.
+func NewLedger() *Ledger {
+	return &Ledger{}
+}
+
+// LoadLedger reads a ledger from its JSON data file form and replays its
+// events.
+func LoadLedger(r io.Reader) (*Ledger, error) {
+	var l Ledger
+	if err := json.NewDecoder(r).Decode(&l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// UnmarshalJSON decodes the ledger's events from its data file form and
+// replays them.
+func (l *Ledger) UnmarshalJSON(b []byte) error {
+	var data struct {
+		Events []Event `json:"events"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	*l = Ledger{Events: data.Events}
+	return l.replay()
+}
+
+// Save writes the ledger in its JSON data file form.
+func (l *Ledger) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(l)
+}
+
+// Create adds a character to the ledger. Names are unique, ignoring case.
+func (l *Ledger) Create(name, class string, gold int) (*Character, error) {
+	if err := l.record(Event{Type: EventCreate, Character: name, Class: class, Gold: gold}); err != nil {
+		return nil, err
+	}
+	return l.Characters[len(l.Characters)-1], nil
+}
+
+// AddGold adds gold to the named character.
+func (l *Ledger) AddGold(name string, gold int) error {
+	if gold < 0 {
+		return fmt.Errorf("gold to add must be at least 0, not %d", gold)
+	}
+	return l.recordFor(name, Event{Type: EventGold, Gold: gold})
+}
+
+// SpendGold takes gold from the named character. It returns an error if the
+// character does not have enough.
+func (l *Ledger) SpendGold(name string, gold int) error {
+	if gold < 0 {
+		return fmt.Errorf("gold to spend must be at least 0, not %d", gold)
+	}
+	return l.recordFor(name, Event{Type: EventGold, Gold: -gold})
+}
+
+// Enhance buys the base enhancement for the named character, as
+// Character.Enhance does, and records the purchase.
+func (l *Ledger) Enhance(name string, be BaseEnhancement, card string, options ...Option) (Cost, error) {
+	c, err := l.Character(name)
+	if err != nil {
+		return 0, err
+	}
+	cost, err := c.Enhance(be, card, options...)
+	if err != nil {
+		return 0, err
+	}
+	p := c.Enhancements[len(c.Enhancements)-1]
+	l.append(Event{Type: EventEnhance, Character: c.Name, Purchase: &p})
+	return cost, nil
+}
+
+// EnhanceSlot buys the base enhancement in the slot of the named character's
+// copy of the named card, as Character.EnhanceSlot does, and records the
+// purchase with the enhanced card. The card comes from Campaign.Card, and
+// becomes the character's only once the purchase succeeds.
+func (l *Ledger) EnhanceSlot(name string, db *CardDatabase, card string, h Half, slot int, be BaseEnhancement, options ...Option) (Cost, error) {
+	c, err := l.Character(name)
+	if err != nil {
+		return 0, err
+	}
+	ac, err := l.Card(c, db, card)
+	if err != nil {
+		return 0, err
+	}
+	cost, err := c.EnhanceSlot(ac, h, slot, be, options...)
+	if err != nil {
+		return 0, err
+	}
+	c.keep(*ac)
+	p, enhanced := c.Enhancements[len(c.Enhancements)-1], ac.clone()
+	l.append(Event{Type: EventEnhance, Character: c.Name, Purchase: &p, Card: &enhanced})
+	return cost, nil
+}
+
+// Retire retires the named character, leaving their cards to their class as
+// Campaign.Retire does.
+func (l *Ledger) Retire(name string) error {
+	return l.recordFor(name, Event{Type: EventRetire})
+}
+
+// Import adds a character as they were recorded elsewhere, such as in a
+// Gloomhaven Secretariat save file, with the gold and enhancements they had
+// there and the source on each enhancement. A retired character leaves their
+// cards to their class. Names are unique, ignoring case.
+func (l *Ledger) Import(c Character, source string) error {
+	c.Enhancements = append([]Purchase(nil), c.Enhancements...)
+	for i := range c.Enhancements {
+		c.Enhancements[i].Source = source
+	}
+	return l.record(Event{Type: EventImport, Character: c.Name, Imported: &c, Source: source})
+}
+
+// Undo reverses the latest change in effect and returns it.
+func (l *Ledger) Undo() (Event, error) {
+	done, _ := stacks(l.Events)
+	if len(done) == 0 {
+		return Event{}, errors.New("nothing to undo")
+	}
+	return done[len(done)-1], l.replayWith(Event{Type: EventUndo})
+}
+
+// Redo restores the latest undone change and returns it. A new change since
+// the undo leaves nothing to redo.
+func (l *Ledger) Redo() (Event, error) {
+	_, undone := stacks(l.Events)
+	if len(undone) == 0 {
+		return Event{}, errors.New("nothing to redo")
+	}
+	return undone[len(undone)-1], l.replayWith(Event{Type: EventRedo})
+}
+
+// History returns the changes in effect, in the order they were made.
+func (l *Ledger) History() []Event {
+	done, _ := stacks(l.Events)
+	return done
+}
+
+// Undone returns the undone changes that Redo can restore, latest last.
+func (l *Ledger) Undone() []Event {
+	_, undone := stacks(l.Events)
+	return undone
+}
+
+// Character returns the character with the name, ignoring case.
+func (l *Ledger) Character(name string) (*Character, error) {
+	for _, c := range l.Characters {
+		if strings.EqualFold(c.Name, name) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no character named %s", name)
+}
+
+// recordFor is a helper method that records the event for the named
+// character, using the name as the ledger has it.
+func (l *Ledger) recordFor(name string, ev Event) error {
+	c, err := l.Character(name)
+	if err != nil {
+		return err
+	}
+	ev.Character = c.Name
+	return l.record(ev)
+}
+
+// record is a helper method that applies the event and appends it to the
+// log, leaving the ledger unchanged if the event does not apply.
+func (l *Ledger) record(ev Event) error {
+	if err := l.apply(ev); err != nil {
+		return err
+	}
+	l.append(ev)
+	return nil
+}
+
+// append is a helper method that appends the applied event to the log,
+// stamping it with the time.
+func (l *Ledger) append(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	l.Events = append(l.Events, ev)
+}
+
+// replayWith is a helper method that appends the undo or redo event and
+// replays the log. It drops the event again if the replay fails.
+func (l *Ledger) replayWith(ev Event) error {
+	l.append(ev)
+	if err := l.replay(); err != nil {
+		l.Events = l.Events[:len(l.Events)-1]
+		return errors.Join(err, l.replay())
+	}
+	return nil
+}
+
+// replay is a helper method that rebuilds the characters and the campaign
+// from the events in effect.
+func (l *Ledger) replay() error {
+	l.Characters = nil
+	l.Campaign = Campaign{}
+	for i, ev := range l.History() {
+		if err := l.apply(ev); err != nil {
+			return fmt.Errorf("event %d (%s): %w", i+1, ev, err)
+		}
+	}
+	return nil
+}
+
+// apply is a helper method that makes the change of the event.
+func (l *Ledger) apply(ev Event) error {
+	switch ev.Type {
+	case EventCreate:
+		return l.create(ev.Character, ev.Class, ev.Gold)
+	case EventImport:
+		return l.importCharacter(ev.Imported)
+	}
+	c, err := l.Character(ev.Character)
+	if err != nil {
+		return err
+	}
+	switch ev.Type {
+	case EventGold:
+		if ev.Gold < 0 {
+			return c.SpendGold(-ev.Gold)
+		}
+		return c.AddGold(ev.Gold)
+	case EventEnhance:
+		if ev.Purchase == nil {
+			return errors.New("an enhance event needs a purchase")
+		}
+		c.Gold -= int(ev.Purchase.Cost)
+		c.Enhancements = append(c.Enhancements, *ev.Purchase)
+		if ev.Card == nil {
+			return nil
+		}
+		c.keep(*ev.Card)
+		return nil
+	case EventRetire:
+		return l.Campaign.Retire(c)
+	default:
+		return fmt.Errorf("cannot apply a %s event", ev.Type)
+	}
+}
+
+// importCharacter is a helper method that adds a copy of the imported
+// character to the ledger, retiring them if they had retired.
+func (l *Ledger) importCharacter(imported *Character) error {
+	if imported == nil {
+		return errors.New("an import event needs a character")
+	}
+	if err := l.create(imported.Name, imported.Class, imported.Gold); err != nil {
+		return err
+	}
+	c := l.Characters[len(l.Characters)-1]
+	c.Level = imported.Level
+	c.Enhancements = append([]Purchase(nil), imported.Enhancements...)
+	for _, card := range imported.Cards {
+		c.Cards = append(c.Cards, card.clone())
+	}
+	if imported.Retired {
+		return l.Campaign.Retire(c)
+	}
+	return nil
+}
+
+// create is a helper method that adds a character to the ledger. Names are
+// unique, ignoring case.
+func (l *Ledger) create(name, class string, gold int) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("a character needs a name")
+	}
+	if strings.TrimSpace(class) == "" {
+		return fmt.Errorf("%s needs a class", name)
+	}
+	if gold < 0 {
+		return fmt.Errorf("gold must be at least 0, not %d", gold)
+	}
+	if _, err := l.Character(name); err == nil {
+		return fmt.Errorf("there is already a character named %s", name)
+	}
+	l.Characters = append(l.Characters, &Character{Name: name, Class: class, Gold: gold})
+	return nil
+}
@@ -0,0 +1,71 @@
+package ghec_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestCharacterEnhanceSpendsGold(t *testing.T) {
+	l := ghec.NewLedger()
+	c, err := l.Create("Grok", "Brute", 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cost, err := c.Enhance(ghec.EnhanceAttack, "Trample", ghec.OptionWithLevel(ghec.Level3), ghec.OptionWithTargets(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != 150 || c.Gold != 50 {
+		t.Fatalf("expected a cost of 150 leaving 50 gold, got %d leaving %d", cost, c.Gold)
+	}
+	want := ghec.Purchase{Enhancement: ghec.EnhanceAttack, Card: "Trample", Level: ghec.Level3, Cost: 150, Game: "gloomhaven"}
+	if len(c.Enhancements) != 1 || c.Enhancements[0] != want {
+		t.Fatalf("expected %+v, got %+v", want, c.Enhancements)
+	}
+}
+
+func TestCharacterCannotOverspend(t *testing.T) {
+	c := &ghec.Character{Name: "Grok", Class: "Brute", Gold: 40}
+	if _, err := c.Enhance(ghec.EnhanceAttack, ""); err == nil {
+		t.Fatal("expected an error for an enhancement the character cannot afford")
+	}
+	if err := c.SpendGold(50); err == nil {
+		t.Fatal("expected an error for spending more gold than the character has")
+	}
+	if c.Gold != 40 || len(c.Enhancements) != 0 {
+		t.Fatalf("expected the character unchanged, got %+v", c)
+	}
+	if err := c.AddGold(-10); err == nil {
+		t.Fatal("expected an error for adding negative gold")
+	}
+}
+
+func TestLedgerRoundTrip(t *testing.T) {
+	l := ghec.NewLedger()
+	if _, err := l.Create("Grok", "Brute", 100); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Enhance("Grok", ghec.EnhanceJump, "Trample"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Create("grok", "Tinkerer", 0); err == nil {
+		t.Fatal("expected an error for a duplicate name")
+	}
+	var buf bytes.Buffer
+	if err := l.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ghec.LoadLedger(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := loaded.Character("GROK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Gold != 50 || len(got.Enhancements) != 1 || got.Enhancements[0].Enhancement != ghec.EnhanceJump {
+		t.Fatalf("unexpected character %+v", got)
+	}
+}
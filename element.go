@@ -0,0 +1,119 @@
+package ghec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Element is an enum of the elements an element enhancement infuses.
+type Element int
+
+// Element* are constants for all the elements, exported for type safety.
+// ElementNone is the zero value, for when the element is not chosen, and
+// ElementWild is the any element enhancement.
+const (
+	ElementNone Element = iota
+	ElementFire
+	ElementIce
+	ElementAir
+	ElementEarth
+	ElementLight
+	ElementDark
+	ElementWild
+)
+
+// Elements returns all the elements, without ElementNone.
+func Elements() []Element {
+	return []Element{ElementFire, ElementIce, ElementAir, ElementEarth, ElementLight, ElementDark, ElementWild}
+}
+
+// String returns the command-line name of the element.
+func (el Element) String() string {
+	switch el {
+	case ElementNone:
+		return "none"
+	case ElementFire:
+		return "fire"
+	case ElementIce:
+		return "ice"
+	case ElementAir:
+		return "air"
+	case ElementEarth:
+		return "earth"
+	case ElementLight:
+		return "light"
+	case ElementDark:
+		return "dark"
+	case ElementWild:
+		return "wild"
+	default:
+		return "unknown"
+	}
+}
+
+// Title returns the name of the element as written in ability text.
+func (el Element) Title() string {
+	if el == ElementWild {
+		return "Any Element"
+	}
+	name := el.String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// ParseElement returns the element with the given name. An empty name is
+// ElementNone.
+func ParseElement(name string) (Element, error) {
+	if name == "" {
+		return ElementNone, nil
+	}
+	for _, el := range Elements() {
+		if el.String() == strings.ToLower(name) {
+			return el, nil
+		}
+	}
+	return ElementNone, fmt.Errorf("unknown element %q, must be one of %v", name, Elements())
+}
+
+// NextElement returns the element after el, wrapping around to ElementNone.
+func NextElement(el Element) Element {
+	return (el + 1) % (ElementWild + 1)
+}
+
+// MarshalText encodes the element as its name, for data files.
+func (el Element) MarshalText() ([]byte, error) {
+	return []byte(el.String()), nil
+}
+
+// UnmarshalText decodes the element from its name, for data files.
+func (el *Element) UnmarshalText(text []byte) error {
+	parsed, err := ParseElement(string(text))
+	if err != nil {
+		return err
+	}
+	*el = parsed
+	return nil
+}
+
+// BaseEnhancement returns the base enhancement that prices the element: any
+// element for wild, and specific element for the others.
+func (el Element) BaseEnhancement() BaseEnhancement {
+	if el == ElementWild {
+		return EnhanceAnyElement
+	}
+	return EnhanceSpecificElement
+}
+
+// checkElement is a helper function that returns an error if the element does
+// not go with the base enhancement.
+func checkElement(be BaseEnhancement, el Element) error {
+	if el == ElementNone {
+		return nil
+	}
+	if Category(be) != CategoryElement {
+		return fmt.Errorf("%s does not infuse an element, so it cannot add %s", Title(be), el)
+	}
+	if el.BaseEnhancement() != be {
+		return fmt.Errorf("%s is priced as %s, not %s", el, Title(el.BaseEnhancement()), Title(be))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestElementsKeepTheirPricing(t *testing.T) {
+	tests := []struct {
+		element ghec.Element
+		base    ghec.BaseEnhancement
+		ok      bool
+	}{
+		{ghec.ElementFire, ghec.EnhanceSpecificElement, true},
+		{ghec.ElementDark, ghec.EnhanceSpecificElement, true},
+		{ghec.ElementWild, ghec.EnhanceAnyElement, true},
+		{ghec.ElementWild, ghec.EnhanceSpecificElement, false},
+		{ghec.ElementIce, ghec.EnhanceAnyElement, false},
+		{ghec.ElementFire, ghec.EnhanceAttack, false},
+		{ghec.ElementNone, ghec.EnhanceAttack, true},
+	}
+	for _, tc := range tests {
+		_, err := ghec.NewEnhancement(tc.base, ghec.OptionWithElement(tc.element)).Cost()
+		if tc.ok && err != nil {
+			t.Fatalf("expected %s with %s to be priced, got %v", ghec.Title(tc.base), tc.element, err)
+		}
+		if !tc.ok && err == nil {
+			t.Fatalf("expected %s with %s to be rejected", ghec.Title(tc.base), tc.element)
+		}
+	}
+	for _, el := range ghec.Elements() {
+		cost, err := ghec.NewEnhancement(el.BaseEnhancement(), ghec.OptionWithElement(el)).Cost()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := ghec.DefaultRuleset().BaseCost(el.BaseEnhancement())
+		if cost != expected {
+			t.Fatalf("expected %s to cost %d, got %d", el, expected, cost)
+		}
+	}
+}
+
+func TestParseElement(t *testing.T) {
+	for _, el := range ghec.Elements() {
+		got, err := ghec.ParseElement(el.String())
+		if err != nil || got != el {
+			t.Fatalf("expected %s, got %s, %v", el, got, err)
+		}
+	}
+	if _, err := ghec.ParseElement("water"); err == nil {
+		t.Fatal("expected an error parsing water")
+	}
+}
+
+func TestRenderElement(t *testing.T) {
+	ability, err := ghec.ParseAbility("Attack 3, Lost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ghec.RenderElement(ability, ghec.ElementFire)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Attack 3, Infuse Fire, Lost" {
+		t.Fatalf("expected %q, got %q", "Attack 3, Infuse Fire, Lost", got)
+	}
+	got, err = ghec.RenderElement(ability, ghec.ElementWild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Attack 3, Infuse Any Element, Lost" {
+		t.Fatalf("expected %q, got %q", "Attack 3, Infuse Any Element, Lost", got)
+	}
+}
+
+func TestAbilityCardKeepsElement(t *testing.T) {
+	card := ghec.AbilityCard{
+		Name:  "Test Card",
+		Level: ghec.Level1,
+		Top: ghec.Action{
+			Text:  "Attack 3",
+			Type:  ghec.ActionAttack,
+			Slots: []ghec.Slot{{Type: ghec.SlotCircle}, {Type: ghec.SlotSquare}},
+		},
+	}
+	if _, err := card.Apply(ghec.HalfTop, 0, ghec.EnhanceSpecificElement, ghec.OptionWithElement(ghec.ElementIce)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := card.Apply(ghec.HalfTop, 1, ghec.EnhanceAttack); err != nil {
+		t.Fatal(err)
+	}
+	if card.Top.Slots[0].Element != ghec.ElementIce {
+		t.Fatalf("expected the slot to keep ice, got %s", card.Top.Slots[0].Element)
+	}
+	got, err := card.Top.Rendered()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Attack 4, Infuse Ice" {
+		t.Fatalf("expected %q, got %q", "Attack 4, Infuse Ice", got)
+	}
+}
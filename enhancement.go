@@ -12,13 +12,38 @@ type enhancement struct {
 	// level is the level of the ability card to calculate the cost.
 	// It must be between 1 and 9.
 	level Level
-	// multipleTarget serves two purposes:
-	// 1. It triggers the multiplier for multiple-target enhancements.
-	// 2. It sets the number of current hexes for Add Attack Hex enhancements.
-	multipleTarget int
+	// targets is the number of targets of the action. More than one triggers
+	// the multiplier for multiple-target enhancements.
+	targets int
+	// hexes is the number of current hexes in the action's area of effect,
+	// which sets the cost of Add Attack Hex enhancements.
+	hexes int
 	// previousEnhancements is the number of previous enhancements on the ability
-	// card. It must be between 0 and 3.
+	// card, or on the action, depending on the ruleset's scope. It must be at
+	// least 0.
 	previousEnhancements PreviousEnhancements
+	// ruleset holds the pricing tables of the game.
+	ruleset Ruleset
+	// lost is whether the action has the lost icon.
+	lost bool
+	// persistent is whether the action has the persistent icon.
+	persistent bool
+	// enhancerLevel is the campaign's Enhancer building level, which discounts
+	// the cost in some games.
+	enhancerLevel EnhancerLevel
+	// slot is the slot type the enhancement goes in, which must accept the
+	// base enhancement.
+	slot SlotType
+	// action is the action type the enhancement goes on, which must suit the
+	// base enhancement.
+	action ActionType
+	// side is whom the action targets, which decides the conditions it can
+	// take.
+	side Side
+	// element is the element that an element enhancement infuses, if it is
+	// chosen. Wild goes with any element, and the others with specific
+	// element.
+	element Element
 }
 
 // newEnhancement creates a new enhancement to calculate its cost.
@@ -27,8 +52,11 @@ func newEnhancement(be BaseEnhancement) enhancement {
 	return enhancement{
 		baseEnhancement:      be,
 		level:                Level1,
-		multipleTarget:       1,
+		targets:              1,
+		hexes:                1,
 		previousEnhancements: PreviousEnhancements0,
+		ruleset:              DefaultRuleset(),
+		enhancerLevel:        EnhancerLevel1,
 	}
 }
 
@@ -51,9 +79,28 @@ func OptionWithLevel(level Level) Option {
 
 // WithMultipleTarget sets the number of targets for the enhancement.
 // It also sets the number of current hexes for Add Attack Hex enhancements.
+//
+// Deprecated: Use OptionWithTargets and OptionWithHexes, which set the two
+// independently.
 func OptionWithMultipleTarget(mt int) Option {
 	return func(e *enhancement) {
-		e.multipleTarget = mt
+		e.targets = mt
+		e.hexes = mt
+	}
+}
+
+// OptionWithTargets sets the number of targets of the action.
+func OptionWithTargets(targets int) Option {
+	return func(e *enhancement) {
+		e.targets = targets
+	}
+}
+
+// OptionWithHexes sets the number of current hexes in the action's area of
+// effect, for Add Attack Hex enhancements.
+func OptionWithHexes(hexes int) Option {
+	return func(e *enhancement) {
+		e.hexes = hexes
 	}
 }
 
@@ -65,39 +112,85 @@ func OptionWithPreviousEnhancements(pe PreviousEnhancements) Option {
 	}
 }
 
-func DecrementPrevious(pe PreviousEnhancements) PreviousEnhancements {
-	// add 4 to avoid negative numbers
-	return (pe - 1 + 4) % 4
+// OptionWithRuleset sets the ruleset that prices the enhancement.
+func OptionWithRuleset(r Ruleset) Option {
+	return func(e *enhancement) {
+		e.ruleset = r
+	}
 }
 
-func IncrementPrevious(pe PreviousEnhancements) PreviousEnhancements {
-	return (pe + 1) % 4
+// OptionLostAction sets whether the enhanced action is a lost action.
+func OptionLostAction(lost bool) Option {
+	return func(e *enhancement) {
+		e.lost = lost
+	}
 }
 
-// Cost calculates the cost of the enhancement.
-// It returns an error if the level or previous enhancements are out of bounds,
-// since the With* methods do not validate their inputs.
-func (e enhancement) Cost() (Cost, error) {
-	if e.level < 1 || e.level > 9 {
-		return 0, fmt.Errorf("level must be between 1 and 9, not %d", e.level)
+// OptionPersistentAction sets whether the enhanced action is a persistent
+// action.
+func OptionPersistentAction(persistent bool) Option {
+	return func(e *enhancement) {
+		e.persistent = persistent
 	}
-	if e.previousEnhancements < 0 || e.previousEnhancements > 3 {
-		return 0, fmt.Errorf("previous enhancements must be between 0 and 3, not %d", e.previousEnhancements)
+}
+
+// OptionWithEnhancerLevel sets the campaign's Enhancer building level.
+func OptionWithEnhancerLevel(el EnhancerLevel) Option {
+	return func(e *enhancement) {
+		e.enhancerLevel = el
 	}
-	baseCost, err := e.costForBaseEnhancement()
-	if err != nil {
-		return 0, err
+}
+
+// OptionWithSlot sets the slot type that the enhancement goes in.
+func OptionWithSlot(s SlotType) Option {
+	return func(e *enhancement) {
+		e.slot = s
 	}
-	levelCost, err := costForLevel(e.level)
-	if err != nil {
-		return 0, err
+}
+
+// OptionWithAction sets the action type that the enhancement goes on.
+func OptionWithAction(a ActionType) Option {
+	return func(e *enhancement) {
+		e.action = a
+	}
+}
+
+// OptionWithSide sets whom the enhanced action targets.
+func OptionWithSide(s Side) Option {
+	return func(e *enhancement) {
+		e.side = s
+	}
+}
+
+// OptionWithElement sets the element that an element enhancement infuses.
+func OptionWithElement(el Element) Option {
+	return func(e *enhancement) {
+		e.element = el
 	}
-	previousEnhancementCost, err := costForPreviousEnhancements(e.previousEnhancements)
+}
+
+// DecrementPrevious returns one fewer previous enhancement, stopping at 0.
+func DecrementPrevious(pe PreviousEnhancements) PreviousEnhancements {
+	return max(pe-1, PreviousEnhancements0)
+}
+
+// IncrementPrevious returns one more previous enhancement. There is no cap,
+// since the number of slots decides how many there can be.
+func IncrementPrevious(pe PreviousEnhancements) PreviousEnhancements {
+	return pe + 1
+}
+
+// Cost calculates the cost of the enhancement by adding up its Breakdown.
+// It returns an error if the ruleset does not allow the base enhancement, if
+// the slot does not accept it, if it does not suit the action, or if the level
+// or previous enhancements are out of bounds, since the With* methods do not
+// validate their inputs.
+func (e enhancement) Cost() (Cost, error) {
+	items, err := e.Breakdown()
 	if err != nil {
 		return 0, err
 	}
-	totalCost := baseCost + levelCost + previousEnhancementCost
-	return totalCost, nil
+	return Total(items), nil
 }
 
 // Cost is the cost of an enhancement.
@@ -139,331 +232,87 @@ const (
 	EnhanceJump
 	EnhanceSpecificElement
 	EnhanceAnyElement
+
+	EnhanceTeleport
+	EnhanceRegenerate
+	EnhanceWard
+
+	// numBaseEnhancements is the number of base enhancements. It must stay
+	// last.
+	numBaseEnhancements
 )
 
+// Title returns the name of the base enhancement.
 func Title(be BaseEnhancement) string {
-	switch be {
-	case EnhanceMove:
-		return "Move"
-	case EnhanceAttack:
-		return "Attack"
-	case EnhanceRange:
-		return "Range"
-	case EnhanceShield:
-		return "Shield"
-	case EnhancePush:
-		return "Push"
-	case EnhancePull:
-		return "Pull"
-	case EnhancePierce:
-		return "Pierce"
-	case EnhanceRetaliate:
-		return "Retaliate"
-	case EnhanceHeal:
-		return "Heal"
-	case EnhanceTarget:
-		return "Target"
-	case EnhancePoison:
-		return "Poison"
-	case EnhanceWound:
-		return "Wound"
-	case EnhanceMuddle:
-		return "Muddle"
-	case EnhanceImmobilize:
-		return "Immobilize"
-	case EnhanceDisarm:
-		return "Disarm"
-	case EnhanceCurse:
-		return "Curse"
-	case EnhanceStrengthen:
-		return "Strengthen"
-	case EnhanceBless:
-		return "Bless"
-	case EnhanceJump:
-		return "Jump"
-	case EnhanceSpecificElement:
-		return "Specific Element"
-	case EnhanceAnyElement:
-		return "Any Element"
-	case EnhanceSummonsMove:
-		return "Summons Move"
-	case EnhanceSummonsAttack:
-		return "Summons Attack"
-	case EnhanceSummonsRange:
-		return "Summons Range"
-	case EnhanceSummonsHP:
-		return "Summons HP"
-	case EnhanceAddAttackHex:
-		return "Add Hex"
-	default:
+	def, ok := lookup(be)
+	if !ok {
 		return "Unknown"
 	}
+	return def.title
 }
 
+// Description describes the base enhancement with its cost in the default
+// ruleset.
 func Description(be BaseEnhancement) string {
-	switch be {
-	case EnhanceMove:
-		return fmt.Sprintf("enhance +1 move (%s)", costForBaseEnhancement(be))
-	case EnhanceAttack:
-		return fmt.Sprintf("enhance +1 attack (%s)", costForBaseEnhancement(be))
-	case EnhanceRange:
-		return fmt.Sprintf("enhance +1 range (%s)", costForBaseEnhancement(be))
-	case EnhanceShield:
-		return fmt.Sprintf("enhance +1 shield (%s)", costForBaseEnhancement(be))
-	case EnhancePush:
-		return fmt.Sprintf("enhance +1 push (%s)", costForBaseEnhancement(be))
-	case EnhancePull:
-		return fmt.Sprintf("enhance +1 pull (%s)", costForBaseEnhancement(be))
-	case EnhancePierce:
-		return fmt.Sprintf("enhance +1 pierce (%s)", costForBaseEnhancement(be))
-	case EnhanceRetaliate:
-		return fmt.Sprintf("enhance +1 retaliate (%s)", costForBaseEnhancement(be))
-	case EnhanceHeal:
-		return fmt.Sprintf("enhance +1 heal (%s)", costForBaseEnhancement(be))
-	case EnhanceTarget:
-		return fmt.Sprintf("enhance +1 target (%s)", costForBaseEnhancement(be))
-	case EnhanceAddAttackHex:
-		return fmt.Sprintf("add attack hex (%s)", costForBaseEnhancement(be))
-	case EnhanceSummonsMove:
-		return fmt.Sprintf("enhance summons +1 move (%s)", costForBaseEnhancement(be))
-	case EnhanceSummonsAttack:
-		return fmt.Sprintf("enhance summons +1 attack (%s)", costForBaseEnhancement(be))
-	case EnhanceSummonsRange:
-		return fmt.Sprintf("enhance summons +1 range (%s)", costForBaseEnhancement(be))
-	case EnhanceSummonsHP:
-		return fmt.Sprintf("enhance summons +1 HP (%s)", costForBaseEnhancement(be))
-	case EnhancePoison:
-		return fmt.Sprintf("add poison effect (%s)", costForBaseEnhancement(be))
-	case EnhanceWound:
-		return fmt.Sprintf("add wound effect (%s)", costForBaseEnhancement(be))
-	case EnhanceMuddle:
-		return fmt.Sprintf("add muddle effect (%s)", costForBaseEnhancement(be))
-	case EnhanceImmobilize:
-		return fmt.Sprintf("add immobilize effect (%s)", costForBaseEnhancement(be))
-	case EnhanceDisarm:
-		return fmt.Sprintf("add disarm effect (%s)", costForBaseEnhancement(be))
-	case EnhanceCurse:
-		return fmt.Sprintf("add curse effect (%s)", costForBaseEnhancement(be))
-	case EnhanceStrengthen:
-		return fmt.Sprintf("add strengthen effect (%s)", costForBaseEnhancement(be))
-	case EnhanceBless:
-		return fmt.Sprintf("add bless effect (%s)", costForBaseEnhancement(be))
-	case EnhanceJump:
-		return fmt.Sprintf("add jump effect (%s)", costForBaseEnhancement(be))
-	case EnhanceSpecificElement:
-		return fmt.Sprintf("add effect: specific element (%s)", costForBaseEnhancement(be))
-	case EnhanceAnyElement:
-		return fmt.Sprintf("add effect: any element (%s)", costForBaseEnhancement(be))
-	default:
+	return DescriptionFor(DefaultRuleset(), be)
+}
+
+// DescriptionFor describes the base enhancement with its cost in the ruleset.
+func DescriptionFor(r Ruleset, be BaseEnhancement) string {
+	def, ok := lookup(be)
+	if !ok {
 		return "unknown effect"
 	}
+	return fmt.Sprintf("%s (%s)", def.description, costForBaseEnhancement(r, be))
 }
 
+// ReverseMap maps every base enhancement to the result of f.
 func ReverseMap[T any](f func(BaseEnhancement) T) map[BaseEnhancement]T {
-	return map[BaseEnhancement]T{
-		EnhanceMove:            f(EnhanceMove),
-		EnhanceAttack:          f(EnhanceAttack),
-		EnhanceRange:           f(EnhanceRange),
-		EnhanceShield:          f(EnhanceShield),
-		EnhancePush:            f(EnhancePush),
-		EnhancePull:            f(EnhancePull),
-		EnhancePierce:          f(EnhancePierce),
-		EnhanceRetaliate:       f(EnhanceRetaliate),
-		EnhanceHeal:            f(EnhanceHeal),
-		EnhanceTarget:          f(EnhanceTarget),
-		EnhanceAddAttackHex:    f(EnhanceAddAttackHex),
-		EnhanceSummonsMove:     f(EnhanceSummonsMove),
-		EnhanceSummonsAttack:   f(EnhanceSummonsAttack),
-		EnhanceSummonsRange:    f(EnhanceSummonsRange),
-		EnhanceSummonsHP:       f(EnhanceSummonsHP),
-		EnhancePoison:          f(EnhancePoison),
-		EnhanceWound:           f(EnhanceWound),
-		EnhanceMuddle:          f(EnhanceMuddle),
-		EnhanceImmobilize:      f(EnhanceImmobilize),
-		EnhanceDisarm:          f(EnhanceDisarm),
-		EnhanceCurse:           f(EnhanceCurse),
-		EnhanceStrengthen:      f(EnhanceStrengthen),
-		EnhanceBless:           f(EnhanceBless),
-		EnhanceJump:            f(EnhanceJump),
-		EnhanceSpecificElement: f(EnhanceSpecificElement),
-		EnhanceAnyElement:      f(EnhanceAnyElement),
+	m := make(map[BaseEnhancement]T, len(registry))
+	for _, def := range registry {
+		m[def.id] = f(def.id)
 	}
+	return m
 }
 
+// Map maps the result of f back to every base enhancement.
 func Map[T comparable](f func(BaseEnhancement) T) map[T]BaseEnhancement {
-	return map[T]BaseEnhancement{
-		f(EnhanceMove):            EnhanceMove,
-		f(EnhanceAttack):          EnhanceAttack,
-		f(EnhanceRange):           EnhanceRange,
-		f(EnhanceShield):          EnhanceShield,
-		f(EnhancePush):            EnhancePush,
-		f(EnhancePull):            EnhancePull,
-		f(EnhancePierce):          EnhancePierce,
-		f(EnhanceRetaliate):       EnhanceRetaliate,
-		f(EnhanceHeal):            EnhanceHeal,
-		f(EnhanceTarget):          EnhanceTarget,
-		f(EnhancePoison):          EnhancePoison,
-		f(EnhanceWound):           EnhanceWound,
-		f(EnhanceMuddle):          EnhanceMuddle,
-		f(EnhanceImmobilize):      EnhanceImmobilize,
-		f(EnhanceDisarm):          EnhanceDisarm,
-		f(EnhanceCurse):           EnhanceCurse,
-		f(EnhanceStrengthen):      EnhanceStrengthen,
-		f(EnhanceBless):           EnhanceBless,
-		f(EnhanceJump):            EnhanceJump,
-		f(EnhanceSpecificElement): EnhanceSpecificElement,
-		f(EnhanceAnyElement):      EnhanceAnyElement,
-		f(EnhanceSummonsMove):     EnhanceSummonsMove,
-		f(EnhanceSummonsAttack):   EnhanceSummonsAttack,
-		f(EnhanceSummonsRange):    EnhanceSummonsRange,
-		f(EnhanceSummonsHP):       EnhanceSummonsHP,
-		f(EnhanceAddAttackHex):    EnhanceAddAttackHex,
+	m := make(map[T]BaseEnhancement, len(registry))
+	for _, def := range registry {
+		m[f(def.id)] = def.id
 	}
+	return m
 }
 
 func identity(be BaseEnhancement) BaseEnhancement {
 	return be
 }
 
+// BaseEnhancements returns all the base enhancements, in registry order.
 func BaseEnhancements() []BaseEnhancement {
 	return List(identity)
 }
 
+// List returns the result of f for every base enhancement, in registry order.
 func List[T any](f func(BaseEnhancement) T) []T {
-	m := ReverseMap(f)
-	list := make([]T, len(m))
-	for k, v := range m {
-		list[k] = v
+	list := make([]T, len(registry))
+	for i, def := range registry {
+		list[i] = f(def.id)
 	}
 	return list
 }
 
 // costForBaseEnhancement is a helper function that returns the base cost for
-// the base enhancement.
-func costForBaseEnhancement(be BaseEnhancement) string {
-	var cost int
-	switch be {
-	case EnhanceMove:
-		cost = 30
-	case EnhanceAttack:
-		cost = 50
-	case EnhanceRange:
-		cost = 30
-	case EnhanceShield:
-		cost = 100
-	case EnhancePush:
-		cost = 30
-	case EnhancePull:
-		cost = 30
-	case EnhancePierce:
-		cost = 30
-	case EnhanceRetaliate:
-		cost = 100
-	case EnhanceHeal:
-		cost = 30
-	case EnhanceTarget:
-		cost = 50
-	case EnhanceAddAttackHex:
-		return "200g / current target hexes"
-	case EnhancePoison:
-		cost = 75
-	case EnhanceWound:
-		cost = 75
-	case EnhanceMuddle:
-		cost = 50
-	case EnhanceImmobilize:
-		cost = 100
-	case EnhanceDisarm:
-		cost = 150
-	case EnhanceCurse:
-		cost = 75
-	case EnhanceStrengthen:
-		cost = 50
-	case EnhanceBless:
-		cost = 50
-	case EnhanceJump:
-		cost = 50
-	case EnhanceSpecificElement:
-		cost = 100
-	case EnhanceAnyElement:
-		cost = 150
-	case EnhanceSummonsMove:
-		cost = 100
-	case EnhanceSummonsAttack:
-		cost = 100
-	case EnhanceSummonsRange:
-		cost = 50
-	case EnhanceSummonsHP:
-		cost = 50
-	}
-	return fmt.Sprintf("%dg", cost)
-}
-
-func (e enhancement) costForBaseEnhancement() (Cost, error) {
-	var cost Cost
-	switch e.baseEnhancement {
-	case EnhanceAddAttackHex:
-		if e.multipleTarget == 0 {
-			return 0, fmt.Errorf("e.multipleTarget is 0")
-		}
-		return Cost(200 / e.multipleTarget), nil
-	case EnhanceMove:
-		cost = 30
-	case EnhanceAttack:
-		cost = 50
-	case EnhanceRange:
-		cost = 30
-	case EnhanceShield:
-		cost = 100
-	case EnhancePush:
-		cost = 30
-	case EnhancePull:
-		cost = 30
-	case EnhancePierce:
-		cost = 30
-	case EnhanceRetaliate:
-		cost = 100
-	case EnhanceHeal:
-		cost = 30
-	case EnhanceTarget:
-		cost = 50
-	case EnhancePoison:
-		cost = 75
-	case EnhanceWound:
-		cost = 75
-	case EnhanceMuddle:
-		cost = 50
-	case EnhanceImmobilize:
-		cost = 100
-	case EnhanceDisarm:
-		cost = 150
-	case EnhanceCurse:
-		cost = 75
-	case EnhanceStrengthen:
-		cost = 50
-	case EnhanceBless:
-		cost = 50
-	case EnhanceJump:
-		cost = 50
-	case EnhanceSpecificElement:
-		cost = 100
-	case EnhanceAnyElement:
-		cost = 150
-	case EnhanceSummonsMove:
-		return 100, nil
-	case EnhanceSummonsAttack:
-		return 100, nil
-	case EnhanceSummonsRange:
-		return 50, nil
-	case EnhanceSummonsHP:
-		return 50, nil
-	default:
-		return 0, fmt.Errorf("unknown base enhancement %d", e.baseEnhancement)
+// the base enhancement in the ruleset.
+func costForBaseEnhancement(r Ruleset, be BaseEnhancement) string {
+	cost, err := r.BaseCost(be)
+	if err != nil {
+		return "n/a"
 	}
-	if e.multipleTarget > 1 {
-		cost *= 2
+	if be == EnhanceAddAttackHex {
+		return fmt.Sprintf("%dg / current target hexes", cost)
 	}
-	return cost, nil
+	return fmt.Sprintf("%dg", cost)
 }
 
 // Level is an enum of all the levels.
@@ -483,59 +332,16 @@ const (
 	Level9 Level = 9
 )
 
-// costForLevel is a helper function that returns the additional cost for the
-// ability card level.
-func costForLevel(level Level) (Cost, error) {
-	switch level {
-	case Level1:
-		return 0, nil
-	case Level2:
-		return 25, nil
-	case Level3:
-		return 50, nil
-	case Level4:
-		return 75, nil
-	case Level5:
-		return 100, nil
-	case Level6:
-		return 125, nil
-	case Level7:
-		return 150, nil
-	case Level8:
-		return 175, nil
-	case Level9:
-		return 200, nil
-	default:
-		return 0, fmt.Errorf("level must be between 1 and 9, not %d", level)
-	}
-}
-
-// PreviousEnhancements is an enum of all the valid values for previous
-// enhancements.
+// PreviousEnhancements is the number of previous enhancements. The ruleset's
+// PreviousScope decides whether it counts the card or the action.
 type PreviousEnhancements int
 
-// PreviousEnhancements* are constants for all the valid values for previous
-// enhancements, exported for type safety.
+// PreviousEnhancements* are constants for the most common values for previous
+// enhancements, exported for type safety. Cards with more slots can have
+// more.
 const (
 	PreviousEnhancements0 PreviousEnhancements = iota
 	PreviousEnhancements1
 	PreviousEnhancements2
 	PreviousEnhancements3
 )
-
-// costForPreviousEnhancements is a helper function that returns the
-// additional cost for the number of previous enhancements.
-func costForPreviousEnhancements(previousEnhancements PreviousEnhancements) (Cost, error) {
-	switch previousEnhancements {
-	case PreviousEnhancements0:
-		return 0, nil
-	case PreviousEnhancements1:
-		return 75, nil
-	case PreviousEnhancements2:
-		return 150, nil
-	case PreviousEnhancements3:
-		return 225, nil
-	default:
-		return 0, fmt.Errorf("previous enhancements must be between 0 and 3, not %d", previousEnhancements)
-	}
-}
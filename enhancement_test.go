@@ -7,12 +7,18 @@ import (
 )
 
 type testCase struct {
-	name     string
-	base     ghec.BaseEnhancement
-	targets  int
-	level    ghec.Level
-	prev     ghec.PreviousEnhancements
-	expected ghec.Cost
+	name       string
+	base       ghec.BaseEnhancement
+	targets    int
+	level      ghec.Level
+	prev       ghec.PreviousEnhancements
+	lost       bool
+	persistent bool
+	enhancer   ghec.EnhancerLevel
+	expected   ghec.Cost
+	// source is the rule or example in the game's rulebook that the case
+	// checks, for the rulesets other than Gloomhaven.
+	source string
 }
 
 var testCases []testCase = []testCase{
@@ -66,6 +72,13 @@ var testCases []testCase = []testCase{
 	},
 }
 
+// rulesetTestCases are the test cases for each ruleset, by name.
+var rulesetTestCases = map[string][]testCase{
+	"gloomhaven":   testCases,
+	"frosthaven":   frosthavenTestCases,
+	"gloomhaven2e": gloomhaven2eTestCases,
+}
+
 func TestFunctionalOptionsAPI(t *testing.T) {
 	for _, tc := range testCases {
 		input := ghec.NewEnhancement(tc.base,
@@ -80,3 +93,187 @@ func TestFunctionalOptionsAPI(t *testing.T) {
 		}
 	}
 }
+
+func TestRulesets(t *testing.T) {
+	for name, cases := range rulesetTestCases {
+		r, err := ghec.RulesetByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tc := range cases {
+			opts := []ghec.Option{
+				ghec.OptionWithRuleset(r),
+				ghec.OptionWithLevel(tc.level),
+				ghec.OptionWithMultipleTarget(tc.targets),
+				ghec.OptionWithPreviousEnhancements(tc.prev),
+				ghec.OptionLostAction(tc.lost),
+				ghec.OptionPersistentAction(tc.persistent),
+			}
+			if tc.enhancer != 0 {
+				opts = append(opts, ghec.OptionWithEnhancerLevel(tc.enhancer))
+			}
+
+			actual, err := ghec.NewEnhancement(tc.base, opts...).Cost()
+			if err != nil {
+				t.Fatalf("%s, %s; unexpected error %v", name, tc.name, err)
+			}
+			if actual != tc.expected {
+				t.Fatalf("%s, %s (%s); expected %d, got %d", name, tc.name, tc.source, tc.expected, actual)
+			}
+		}
+	}
+}
+
+func TestUnknownRuleset(t *testing.T) {
+	if _, err := ghec.RulesetByName("descent"); err == nil {
+		t.Fatal("expected an error for an unknown game")
+	}
+}
+
+func TestRulesetsPriceTheirEnhancements(t *testing.T) {
+	for _, r := range ghec.Rulesets() {
+		for _, be := range r.Enhancements() {
+			if _, err := r.BaseCost(be); err != nil {
+				t.Fatalf("%s; %s has no base cost: %v", r.Name(), ghec.Title(be), err)
+			}
+		}
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	items, err := ghec.NewEnhancement(ghec.EnhanceAddAttackHex,
+		ghec.OptionWithLevel(ghec.Level3),
+		ghec.OptionWithMultipleTarget(3),
+		ghec.OptionWithPreviousEnhancements(ghec.PreviousEnhancements1),
+	).Breakdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ghec.LineItem{
+		{Label: "Add Hex base cost, 200g / 3 current hexes, rounded down", Cost: 66},
+		{Label: "card level (3)", Cost: 50},
+		{Label: "previous enhancements (1)", Cost: 75},
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, items)
+	}
+	for i := range expected {
+		if items[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected[i], items[i])
+		}
+	}
+	if ghec.Total(items) != 191 {
+		t.Fatalf("expected a total of 191, got %d", ghec.Total(items))
+	}
+}
+
+func TestTargetsAndHexes(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     ghec.BaseEnhancement
+		targets  int
+		hexes    int
+		expected ghec.Cost
+	}{
+		{"single-target action with a three-hex AoE", ghec.EnhanceAddAttackHex, 1, 3, 66},
+		{"multiple-target ranged attack without an AoE", ghec.EnhanceAttack, 2, 1, 100},
+		{"single-target attack with a three-hex AoE", ghec.EnhanceAttack, 1, 3, 50},
+	}
+	for _, tc := range tests {
+		actual, err := ghec.NewEnhancement(tc.base,
+			ghec.OptionWithTargets(tc.targets),
+			ghec.OptionWithHexes(tc.hexes),
+		).Cost()
+		if err != nil {
+			t.Fatalf("%s; unexpected error %v", tc.name, err)
+		}
+		if actual != tc.expected {
+			t.Fatalf("%s; expected %d, got %d", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestCategories(t *testing.T) {
+	var grouped int
+	for _, c := range ghec.Categories() {
+		grouped += len(ghec.InCategory(c, ghec.BaseEnhancements()))
+		if parsed, ok := ghec.ParseCategory(c.Slug()); !ok || parsed != c {
+			t.Fatalf("expected %q to parse as %s", c.Slug(), c)
+		}
+	}
+	if grouped != len(ghec.BaseEnhancements()) {
+		t.Fatalf("expected every base enhancement in a category, got %d of %d", grouped, len(ghec.BaseEnhancements()))
+	}
+	if ghec.Category(ghec.EnhanceJump) != ghec.CategoryMovement {
+		t.Fatalf("expected jump to be a movement effect, got %s", ghec.Category(ghec.EnhanceJump))
+	}
+}
+
+func TestSlots(t *testing.T) {
+	tests := []struct {
+		slot     ghec.SlotType
+		base     ghec.BaseEnhancement
+		accepted bool
+	}{
+		{ghec.SlotSquare, ghec.EnhanceAttack, true},
+		{ghec.SlotSquare, ghec.EnhancePoison, false},
+		{ghec.SlotCircle, ghec.EnhanceSpecificElement, true},
+		{ghec.SlotCircle, ghec.EnhancePoison, false},
+		{ghec.SlotDiamond, ghec.EnhancePoison, true},
+		{ghec.SlotDiamond, ghec.EnhanceBless, false},
+		{ghec.SlotDiamondPlus, ghec.EnhanceBless, true},
+		{ghec.SlotHex, ghec.EnhanceAnyElement, false},
+		{ghec.SlotHex, ghec.EnhanceAddAttackHex, true},
+		{ghec.SlotAny, ghec.EnhanceDisarm, true},
+	}
+	for _, tc := range tests {
+		_, err := ghec.NewEnhancement(tc.base,
+			ghec.OptionWithSlot(tc.slot),
+		).Cost()
+		if tc.accepted && err != nil {
+			t.Fatalf("expected a %s slot to take %s, got %v", tc.slot, ghec.Title(tc.base), err)
+		}
+		if !tc.accepted && err == nil {
+			t.Fatalf("expected a %s slot to reject %s", tc.slot, ghec.Title(tc.base))
+		}
+	}
+}
+
+func TestActionTypes(t *testing.T) {
+	tests := []struct {
+		action  ghec.ActionType
+		side    ghec.Side
+		base    ghec.BaseEnhancement
+		allowed bool
+	}{
+		{ghec.ActionMove, ghec.SideAny, ghec.EnhanceJump, true},
+		{ghec.ActionAttack, ghec.SideAny, ghec.EnhanceJump, false},
+		{ghec.ActionAttack, ghec.SideAny, ghec.EnhancePierce, true},
+		{ghec.ActionHeal, ghec.SideAny, ghec.EnhancePierce, false},
+		{ghec.ActionAttack, ghec.SideAny, ghec.EnhanceAddAttackHex, true},
+		{ghec.ActionOther, ghec.SideAny, ghec.EnhanceAddAttackHex, false},
+		{ghec.ActionSummon, ghec.SideAny, ghec.EnhanceSummonsHP, true},
+		{ghec.ActionAttack, ghec.SideAny, ghec.EnhanceSummonsHP, false},
+		{ghec.ActionAttack, ghec.SideAny, ghec.EnhancePoison, true},
+		{ghec.ActionHeal, ghec.SideAny, ghec.EnhancePoison, false},
+		{ghec.ActionOther, ghec.SideAllies, ghec.EnhancePoison, false},
+		{ghec.ActionHeal, ghec.SideAny, ghec.EnhanceBless, true},
+		{ghec.ActionAttack, ghec.SideAny, ghec.EnhanceBless, false},
+		{ghec.ActionOther, ghec.SideEnemies, ghec.EnhanceBless, false},
+		{ghec.ActionOther, ghec.SideAllies, ghec.EnhanceBless, true},
+		{ghec.ActionAny, ghec.SideAny, ghec.EnhanceJump, true},
+		{ghec.ActionSummon, ghec.SideAny, ghec.EnhanceSpecificElement, true},
+	}
+	for _, tc := range tests {
+		_, err := ghec.NewEnhancement(tc.base,
+			ghec.OptionWithAction(tc.action),
+			ghec.OptionWithSide(tc.side),
+		).Cost()
+		if tc.allowed && err != nil {
+			t.Fatalf("expected a %s action targeting %s to take %s, got %v", tc.action, tc.side, ghec.Title(tc.base), err)
+		}
+		if !tc.allowed && err == nil {
+			t.Fatalf("expected a %s action targeting %s to reject %s", tc.action, tc.side, ghec.Title(tc.base))
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package ghec
+
+// EnhancerLevel is the upgrade level of the Frosthaven Enhancer building.
+// It is a campaign modifier: it applies to every enhancement the campaign
+// buys, rather than describing the card or the action.
+type EnhancerLevel int
+
+// EnhancerLevel* are constants for all the building levels, exported for type
+// safety.
+const (
+	EnhancerLevel1 EnhancerLevel = iota + 1
+	EnhancerLevel2
+	EnhancerLevel3
+	EnhancerLevel4
+)
+
+// IncrementEnhancerLevel returns the next building level, wrapping around to
+// the first.
+func IncrementEnhancerLevel(el EnhancerLevel) EnhancerLevel {
+	return el%EnhancerLevel4 + 1
+}
+
+// Discount holds the flat discounts that an Enhancer building level grants.
+type Discount struct {
+	// Base is subtracted from the base cost.
+	Base Cost
+	// PerLevel is subtracted from the level surcharge for each card level
+	// above 1.
+	PerLevel Cost
+	// PerPrevious is subtracted from the previous enhancements surcharge for
+	// each previous enhancement.
+	PerPrevious Cost
+}
+
+// applicableDiscount is a helper function that caps the discount at the cost,
+// so a discount never makes a cost negative.
+func applicableDiscount(cost, discount Cost) Cost {
+	if discount > cost {
+		return cost
+	}
+	return discount
+}
@@ -0,0 +1,160 @@
+package ghec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EventType is an enum of the changes a ledger records.
+type EventType int
+
+// Event* are constants for the changes a ledger records. EventUndo and
+// EventRedo reverse and restore the latest change, so the log only grows.
+const (
+	EventCreate EventType = iota
+	EventGold
+	EventEnhance
+	EventRetire
+	EventImport
+	EventUndo
+	EventRedo
+)
+
+// eventTypeNames are the names of the event types, in their order.
+var eventTypeNames = []string{"create", "gold", "enhance", "retire", "import", "undo", "redo"}
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	if t < 0 || int(t) >= len(eventTypeNames) {
+		return "unknown"
+	}
+	return eventTypeNames[t]
+}
+
+// ParseEventType returns the event type with the name.
+func ParseEventType(name string) (EventType, error) {
+	for i, n := range eventTypeNames {
+		if strings.EqualFold(name, n) {
+			return EventType(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown event type %q, must be one of %v", name, eventTypeNames)
+}
+
+// MarshalText encodes the event type as its name, for data files.
+func (t EventType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes the event type from its name, for data files.
+func (t *EventType) UnmarshalText(text []byte) error {
+	parsed, err := ParseEventType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// Event is a change to the characters or the campaign. Replaying the events
+// in order rebuilds the ledger.
+type Event struct {
+	// Type is the kind of change.
+	Type EventType `json:"type"`
+	// Time is when the change was made.
+	Time time.Time `json:"time"`
+	// Character is the name of the character the change is to.
+	Character string `json:"character,omitempty"`
+	// Class is the class of a created character.
+	Class string `json:"class,omitempty"`
+	// Gold is the starting gold of a created character, or the gold a
+	// character gained, or spent if it is negative.
+	Gold int `json:"gold,omitempty"`
+	// Purchase is the enhancement a character bought, as it was priced then.
+	Purchase *Purchase `json:"purchase,omitempty"`
+	// Card is the card an enhancement was applied to, as it was afterwards,
+	// if the enhancement went in one of its slots.
+	Card *AbilityCard `json:"card,omitempty"`
+	// Imported is a character as it was recorded elsewhere, with the gold and
+	// enhancements they had there.
+	Imported *Character `json:"imported,omitempty"`
+	// Source is where an imported character was recorded.
+	Source string `json:"source,omitempty"`
+}
+
+// String describes the change.
+func (ev Event) String() string {
+	switch ev.Type {
+	case EventCreate:
+		return fmt.Sprintf("Created %s (%s) with %d gold", ev.Character, ev.Class, ev.Gold)
+	case EventGold:
+		if ev.Gold < 0 {
+			return fmt.Sprintf("%s spent %d gold", ev.Character, -ev.Gold)
+		}
+		return fmt.Sprintf("%s gained %d gold", ev.Character, ev.Gold)
+	case EventEnhance:
+		if ev.Purchase == nil {
+			return fmt.Sprintf("%s bought an enhancement", ev.Character)
+		}
+		return fmt.Sprintf("%s bought %s", ev.Character, ev.Purchase)
+	case EventRetire:
+		return fmt.Sprintf("%s retired", ev.Character)
+	case EventImport:
+		if ev.Imported == nil {
+			return fmt.Sprintf("Imported %s", ev.Character)
+		}
+		c := ev.Imported
+		s := fmt.Sprintf("Imported %s (%s) with %d gold and %d enhancements", c.Name, c.Class, c.Gold, len(c.Enhancements))
+		if ev.Source != "" {
+			s += " from " + ev.Source
+		}
+		return s
+	default:
+		return ev.Type.String()
+	}
+}
+
+// String describes the purchase with what it cost in the game it was
+// priced in, or where it was imported from.
+func (p Purchase) String() string {
+	card := p.Card
+	if card == "" {
+		card = "a card"
+	}
+	if p.Level > 0 {
+		card += fmt.Sprintf(" (level %d)", p.Level)
+	}
+	if p.Source != "" {
+		return fmt.Sprintf("%s on %s from %s", Title(p.Enhancement), card, p.Source)
+	}
+	s := fmt.Sprintf("%s on %s for %d", Title(p.Enhancement), card, p.Cost)
+	if p.Game != "" {
+		s += fmt.Sprintf(" in %s", p.Game)
+	}
+	return s
+}
+
+// stacks is a helper function that splits the events into the changes in
+// effect, in order, and the undone changes that can be redone, latest last.
+// A new change clears the undone changes.
+func stacks(events []Event) (done, undone []Event) {
+	for _, ev := range events {
+		switch ev.Type {
+		case EventUndo:
+			if len(done) > 0 {
+				undone = append(undone, done[len(done)-1])
+				done = done[:len(done)-1]
+			}
+		case EventRedo:
+			if len(undone) > 0 {
+				done = append(done, undone[len(undone)-1])
+				undone = undone[:len(undone)-1]
+			}
+		default:
+			done = append(done, ev)
+			undone = nil
+		}
+	}
+	return done, undone
+}
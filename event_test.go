@@ -0,0 +1,117 @@
+package ghec_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestLedgerUndoRedo(t *testing.T) {
+	l := ghec.NewLedger()
+	if _, err := l.Create("Grok", "Brute", 100); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Enhance("Grok", ghec.EnhanceAttack, "Trample"); err != nil {
+		t.Fatal(err)
+	}
+	ev, err := l.Undo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.Type != ghec.EventEnhance {
+		t.Fatalf("expected to undo the purchase, got %s", ev)
+	}
+	c, err := l.Character("Grok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Gold != 100 || len(c.Enhancements) != 0 {
+		t.Fatalf("expected the purchase undone, got %+v", c)
+	}
+	if _, err := l.Redo(); err != nil {
+		t.Fatal(err)
+	}
+	c, err = l.Character("Grok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Gold != 50 || len(c.Enhancements) != 1 {
+		t.Fatalf("expected the purchase redone, got %+v", c)
+	}
+	if _, err := l.Redo(); err == nil {
+		t.Fatal("expected nothing to redo")
+	}
+}
+
+func TestLedgerNewChangeClearsRedo(t *testing.T) {
+	l := ghec.NewLedger()
+	if _, err := l.Create("Grok", "Brute", 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddGold("Grok", 30); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SpendGold("grok", 20); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Redo(); err == nil {
+		t.Fatal("expected a new change to clear the undone one")
+	}
+	if len(l.Events) != 4 {
+		t.Fatalf("expected the log to keep all 4 events, got %d", len(l.Events))
+	}
+	history := l.History()
+	if len(history) != 2 || history[1].String() != "Grok spent 20 gold" {
+		t.Fatalf("unexpected history %v", history)
+	}
+}
+
+func TestLedgerReplaysEvents(t *testing.T) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := ghec.NewLedger()
+	if _, err := l.Create("Grok", "Brute", 300); err != nil {
+		t.Fatal(err)
+	}
+	frosthaven := ghec.OptionWithRuleset(ghec.Frosthaven{})
+	if _, err := l.EnhanceSlot("Grok", db, "Trample", ghec.HalfTop, 0, ghec.EnhanceAttack, frosthaven); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Retire("Grok"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := l.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ghec.LoadLedger(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := loaded.Character("Grok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Retired || c.Gold != 300-int(c.Enhancements[0].Cost) {
+		t.Fatalf("unexpected character %+v", c)
+	}
+	if game := c.Enhancements[0].Game; game != "frosthaven" {
+		t.Fatalf("expected the purchase priced in frosthaven, got %q", game)
+	}
+	inherited := loaded.Cards("Brute")
+	if len(inherited) != 1 || !inherited[0].Top.Slots[0].Filled {
+		t.Fatalf("expected the class to inherit the enhanced card, got %+v", inherited)
+	}
+	if _, err := loaded.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Cards("Brute")) != 0 {
+		t.Fatal("expected undoing the retirement to take the cards back from the class")
+	}
+}
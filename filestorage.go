@@ -0,0 +1,257 @@
+package ghec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileStorage is a Storage that keeps each key in a file of its own under a
+// directory, in YAML or JSON, so the files can be read and edited by hand.
+type fileStorage struct {
+	// dir is the directory that holds the files.
+	dir string
+	// format is the file format, StorageYAML or StorageJSON.
+	format string
+}
+
+// NewFileStorage returns a Storage that keeps each key in a YAML or JSON
+// file under the directory, such as parties/default.yaml for the default
+// party's ledger. It creates the directory if it is missing.
+func NewFileStorage(dir, format string) (Storage, error) {
+	if format != StorageYAML && format != StorageJSON {
+		return nil, fmt.Errorf("unknown file format %q, must be %s or %s", format, StorageYAML, StorageJSON)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileStorage{dir: dir, format: format}, nil
+}
+
+func (s *fileStorage) Load(key string, v any) error {
+	unlock, err := s.lock(key, false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.read(key, v)
+}
+
+func (s *fileStorage) Save(key string, v any) error {
+	unlock, err := s.lock(key, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.write(key, v)
+}
+
+func (s *fileStorage) Update(key string, v any, f func() error) error {
+	unlock, err := s.lock(key, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := s.read(key, v); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	return s.write(key, v)
+}
+
+func (s *fileStorage) Delete(key string) error {
+	unlock, err := s.lock(key, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: %w", key, ErrNotFound)
+	}
+	return err
+}
+
+func (s *fileStorage) Keys() ([]string, error) {
+	unlock, err := lockPath(filepath.Join(s.dir, ".lock"), false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	var keys []string
+	ext := "." + s.format
+	err = filepath.WalkDir(s.dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && name != s.dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || filepath.Ext(name) != ext {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, strings.TrimSuffix(name, ext))
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+func (s *fileStorage) Close() error {
+	return nil
+}
+
+// lock is a helper method that checks the key and locks the storage. One lock
+// file covers the directory, so Keys sees a consistent set of files.
+func (s *fileStorage) lock(key string, exclusive bool) (func() error, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	return lockPath(filepath.Join(s.dir, ".lock"), exclusive)
+}
+
+// path is a helper method that returns the name of the file for the key.
+func (s *fileStorage) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key)+"."+s.format)
+}
+
+// read is a helper method that decodes the file for the key into v.
+func (s *fileStorage) read(key string, v any) error {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: %w", key, ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if s.format == StorageYAML {
+		if data, err = yamlToJSON(data); err != nil {
+			return fmt.Errorf("%s: %w", s.path(key), err)
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", s.path(key), err)
+	}
+	return nil
+}
+
+// write is a helper method that encodes v into the file for the key.
+func (s *fileStorage) write(key string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if s.format == StorageYAML {
+		if data, err = jsonToYAML(data); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(s.path(key), data)
+}
+
+// jsonToYAML is a helper function that converts JSON to the same document in
+// block-style YAML, keeping the order of the keys.
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	var block func(*yaml.Node)
+	block = func(n *yaml.Node) {
+		n.Style = 0
+		for _, c := range n.Content {
+			block(c)
+		}
+	}
+	block(&node)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlToJSON is a helper function that converts a YAML document to JSON, so
+// values decode with their JSON tags and methods.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return []byte("null"), nil
+	}
+	v, err := yamlValue(node.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// yamlValue is a helper function that returns the value of the YAML node in
+// the form json.Marshal encodes. Timestamps stay strings, which is how JSON
+// holds them.
+func yamlValue(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return yamlValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := yamlValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = v
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		list := make([]any, len(n.Content))
+		for i, c := range n.Content {
+			v, err := yamlValue(c)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = v
+		}
+		return list, nil
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!null":
+			return nil, nil
+		case "!!bool", "!!int", "!!float":
+			var v any
+			if err := n.Decode(&v); err != nil {
+				return nil, err
+			}
+			return v, nil
+		default:
+			return n.Value, nil
+		}
+	default:
+		return nil, fmt.Errorf("line %d: unexpected YAML node", n.Line)
+	}
+}
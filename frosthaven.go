@@ -0,0 +1,138 @@
+package ghec
+
+import "fmt"
+
+// Frosthaven is the ruleset for Frosthaven.
+type Frosthaven struct{}
+
+// frosthavenCosts holds the base costs of the base enhancements that the game
+// allows.
+var frosthavenCosts = costTable{
+	EnhanceMove:            30,
+	EnhanceAttack:          50,
+	EnhanceRange:           30,
+	EnhanceShield:          80,
+	EnhancePush:            30,
+	EnhancePull:            20,
+	EnhancePierce:          30,
+	EnhanceRetaliate:       60,
+	EnhanceHeal:            30,
+	EnhanceTarget:          75,
+	EnhanceTeleport:        50,
+	EnhanceAddAttackHex:    200,
+	EnhancePoison:          50,
+	EnhanceWound:           75,
+	EnhanceMuddle:          40,
+	EnhanceImmobilize:      150,
+	EnhanceCurse:           150,
+	EnhanceStrengthen:      100,
+	EnhanceBless:           75,
+	EnhanceRegenerate:      40,
+	EnhanceWard:            75,
+	EnhanceJump:            60,
+	EnhanceSpecificElement: 100,
+	EnhanceAnyElement:      150,
+	EnhanceSummonsMove:     60,
+	EnhanceSummonsAttack:   100,
+	EnhanceSummonsRange:    50,
+	EnhanceSummonsHP:       40,
+}
+
+// Name is the name of the game, as accepted by RulesetByName.
+func (Frosthaven) Name() string {
+	return "frosthaven"
+}
+
+// Enhancements returns the base enhancements that the game allows, which are
+// all of them except disarm.
+func (Frosthaven) Enhancements() []BaseEnhancement {
+	return frosthavenCosts.enhancements()
+}
+
+// BaseCost returns the base cost of the base enhancement.
+// Frosthaven has no disarm enhancement.
+func (r Frosthaven) BaseCost(be BaseEnhancement) (Cost, error) {
+	return frosthavenCosts.baseCost(r, be)
+}
+
+// LevelCost returns the additional cost for the ability card level, which is
+// 25 gold for each level above 1.
+func (Frosthaven) LevelCost(level Level) (Cost, error) {
+	if level < Level1 || level > Level9 {
+		return 0, fmt.Errorf("level must be between 1 and 9, not %d", level)
+	}
+	return Cost(25 * (level - 1)), nil
+}
+
+// PreviousCost returns the additional cost for the number of previous
+// enhancements, which is 75 gold for each.
+func (Frosthaven) PreviousCost(pe PreviousEnhancements) (Cost, error) {
+	if pe < PreviousEnhancements0 {
+		return 0, fmt.Errorf("previous enhancements must be at least 0, not %d", pe)
+	}
+	return Cost(75 * pe), nil
+}
+
+// PreviousScope counts only the enhancements on the same action as previous
+// enhancements.
+func (Frosthaven) PreviousScope() PreviousScope {
+	return ScopeAction
+}
+
+// DoublesForMultipleTargets reports whether the base cost doubles for
+// multiple targets. Target, Add Attack Hex, elements, and summons stats never
+// double.
+func (Frosthaven) DoublesForMultipleTargets(be BaseEnhancement) bool {
+	switch be {
+	case EnhanceTarget, EnhanceAddAttackHex:
+		return false
+	case EnhanceSpecificElement, EnhanceAnyElement:
+		return false
+	case EnhanceSummonsMove, EnhanceSummonsAttack, EnhanceSummonsRange, EnhanceSummonsHP:
+		return false
+	default:
+		return true
+	}
+}
+
+// AddHexCost returns 200 gold divided by the number of current hexes,
+// rounded down.
+func (r Frosthaven) AddHexCost(hexes int) (Cost, error) {
+	return addHexCost(r, hexes)
+}
+
+// LostActionCost halves the cost of an enhancement on a lost action,
+// rounded down.
+func (Frosthaven) LostActionCost(_ BaseEnhancement, cost Cost) Cost {
+	return cost / 2
+}
+
+// PersistentActionCost triples the cost of an enhancement on a persistent
+// action, except for summons stats.
+func (Frosthaven) PersistentActionCost(be BaseEnhancement, cost Cost) Cost {
+	switch be {
+	case EnhanceSummonsMove, EnhanceSummonsAttack, EnhanceSummonsRange, EnhanceSummonsHP:
+		return cost
+	default:
+		return cost * 3
+	}
+}
+
+// EnhancerDiscount returns the discounts for the Enhancer building level.
+// Each level keeps the discounts of the levels below it: level 2 takes 10 gold
+// off the base cost, level 3 takes 10 gold off each card level surcharge, and
+// level 4 takes 25 gold off each previous enhancement surcharge.
+func (Frosthaven) EnhancerDiscount(el EnhancerLevel) (Discount, error) {
+	switch el {
+	case EnhancerLevel1:
+		return Discount{}, nil
+	case EnhancerLevel2:
+		return Discount{Base: 10}, nil
+	case EnhancerLevel3:
+		return Discount{Base: 10, PerLevel: 10}, nil
+	case EnhancerLevel4:
+		return Discount{Base: 10, PerLevel: 10, PerPrevious: 25}, nil
+	default:
+		return Discount{}, fmt.Errorf("enhancer level must be between 1 and 4, not %d", el)
+	}
+}
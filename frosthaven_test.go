@@ -0,0 +1,195 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+// frosthavenTestCases price enhancements by the rules in the Enhancements
+// section of the Frosthaven rulebook and on the Enhancer building card. Each
+// case checks one rule, which its source names.
+var frosthavenTestCases []testCase = []testCase{
+	{
+		name:     "add move to a level 1 card",
+		base:     ghec.EnhanceMove,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(30),
+		source:   "Frosthaven rulebook, Enhancements: base cost table",
+	},
+	{
+		name:     "add teleport to a level 1 card",
+		base:     ghec.EnhanceTeleport,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(50),
+		source:   "Frosthaven rulebook, Enhancements: base cost table",
+	},
+	{
+		name:     "add ward to a level 4 card",
+		base:     ghec.EnhanceWard,
+		targets:  1,
+		level:    ghec.Level4,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(150),
+		source:   "Frosthaven rulebook, Enhancements: 25 gold for each card level above 1",
+	},
+	{
+		name:     "add pull with two previous enhancements on the action",
+		base:     ghec.EnhancePull,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements2,
+		expected: ghec.Cost(170),
+		source:   "Frosthaven rulebook, Enhancements: 75 gold for each enhancement already on the action",
+	},
+	{
+		name:     "add attack to an ability with two targets",
+		base:     ghec.EnhanceAttack,
+		targets:  2,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(100),
+		source:   "Frosthaven rulebook, Enhancements: double the base cost for multiple targets",
+	},
+	{
+		name:     "add target is not doubled",
+		base:     ghec.EnhanceTarget,
+		targets:  3,
+		level:    ghec.Level2,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(100),
+		source:   "Frosthaven rulebook, Enhancements: target, hex, and element costs never double",
+	},
+	{
+		name:     "add specific element is not doubled",
+		base:     ghec.EnhanceSpecificElement,
+		targets:  2,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(100),
+		source:   "Frosthaven rulebook, Enhancements: target, hex, and element costs never double",
+	},
+	{
+		name:     "add hex to a three-hex area",
+		base:     ghec.EnhanceAddAttackHex,
+		targets:  3,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(66),
+		source:   "Frosthaven rulebook, Enhancements: 200 gold divided by the current hexes",
+	},
+	{
+		name:     "add summons move, which has its own table",
+		base:     ghec.EnhanceSummonsMove,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements1,
+		expected: ghec.Cost(135),
+		source:   "Frosthaven rulebook, Enhancements: summon base cost table",
+	},
+	{
+		name:     "attack on a lost action is halved",
+		base:     ghec.EnhanceAttack,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		lost:     true,
+		expected: ghec.Cost(25),
+		source:   "Frosthaven rulebook, Enhancements: halve the base cost of a lost action",
+	},
+	{
+		name:     "halving follows the multiple-target doubling",
+		base:     ghec.EnhanceAttack,
+		targets:  2,
+		level:    ghec.Level2,
+		prev:     ghec.PreviousEnhancements0,
+		lost:     true,
+		expected: ghec.Cost(75),
+		source:   "Frosthaven rulebook, Enhancements: double, then halve, then add the surcharges",
+	},
+	{
+		name:       "shield on a persistent lost action is tripled, not halved",
+		base:       ghec.EnhanceShield,
+		targets:    1,
+		level:      ghec.Level1,
+		prev:       ghec.PreviousEnhancements1,
+		lost:       true,
+		persistent: true,
+		expected:   ghec.Cost(315),
+		source:     "Frosthaven rulebook, Enhancements: triple the base cost of a persistent action instead",
+	},
+	{
+		name:       "summons stats on a persistent action are not tripled",
+		base:       ghec.EnhanceSummonsHP,
+		targets:    1,
+		level:      ghec.Level1,
+		prev:       ghec.PreviousEnhancements0,
+		persistent: true,
+		expected:   ghec.Cost(40),
+		source:     "Frosthaven rulebook, Enhancements: summon stats are never tripled",
+	},
+	{
+		name:     "enhancer level 1 has no discount",
+		base:     ghec.EnhanceAttack,
+		targets:  1,
+		level:    ghec.Level3,
+		prev:     ghec.PreviousEnhancements1,
+		enhancer: ghec.EnhancerLevel1,
+		expected: ghec.Cost(175),
+		source:   "Frosthaven Enhancer building, level 1",
+	},
+	{
+		name:     "enhancer level 2 discounts the base cost",
+		base:     ghec.EnhanceAttack,
+		targets:  1,
+		level:    ghec.Level3,
+		prev:     ghec.PreviousEnhancements1,
+		enhancer: ghec.EnhancerLevel2,
+		expected: ghec.Cost(165),
+		source:   "Frosthaven Enhancer building, level 2: 10 gold off",
+	},
+	{
+		name:     "enhancer level 3 also discounts the level surcharge",
+		base:     ghec.EnhanceAttack,
+		targets:  1,
+		level:    ghec.Level3,
+		prev:     ghec.PreviousEnhancements1,
+		enhancer: ghec.EnhancerLevel3,
+		expected: ghec.Cost(145),
+		source:   "Frosthaven Enhancer building, level 3: 10 gold off each level",
+	},
+	{
+		name:     "enhancer level 4 also discounts the previous surcharge",
+		base:     ghec.EnhanceAttack,
+		targets:  1,
+		level:    ghec.Level3,
+		prev:     ghec.PreviousEnhancements1,
+		enhancer: ghec.EnhancerLevel4,
+		expected: ghec.Cost(120),
+		source:   "Frosthaven Enhancer building, level 4: 25 gold off each previous enhancement",
+	},
+	{
+		name:     "the base discount follows the lost action halving",
+		base:     ghec.EnhancePull,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		lost:     true,
+		enhancer: ghec.EnhancerLevel2,
+		expected: ghec.Cost(0),
+		source:   "Frosthaven Enhancer building, level 2: 10 gold off the halved cost",
+	},
+}
+
+func TestFrosthavenHasNoDisarm(t *testing.T) {
+	_, err := ghec.NewEnhancement(ghec.EnhanceDisarm,
+		ghec.OptionWithRuleset(ghec.Frosthaven{}),
+	).Cost()
+	if err == nil {
+		t.Fatal("expected an error for disarm in frosthaven")
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// cardsCmd represents the cards command
+var cardsCmd = &cobra.Command{
+	Use:   "cards [name]",
+	Short: "Search the ability cards and their enhancement slots",
+	Long: `
+    Cards shows the ability cards whose names contain the search text, with
+    the text of each action and the enhancement slots beside each ability.
+    Use the --class flag to show one class, and the --card-data flag or the
+    card-data config key to load data files for other classes.
+    `,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		db, err := cardDatabase()
+		cobra.CheckErr(err)
+		class, _ := cmd.Flags().GetString("class")
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		cards := db.Search(class, name)
+		if len(cards) == 0 {
+			cobra.CheckErr(fmt.Errorf("no cards match, the classes are %v", db.Classes()))
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, card := range cards {
+			fmt.Fprintf(w, "%s\t%d\t%s\ttop\t%s\t%s\n", card.Class, card.Level, card.Name, actionText(card.Top), slotsText(card.Top))
+			fmt.Fprintf(w, "\t\t\tbottom\t%s\t%s\n", actionText(card.Bottom), slotsText(card.Bottom))
+		}
+		w.Flush()
+	},
+}
+
+// actionText is a helper function that returns the text of the action with
+// its lost and persistent icons.
+func actionText(a ghec.Action) string {
+	text := a.Text
+	if a.Lost {
+		text += " (lost)"
+	}
+	if a.Persistent {
+		text += " (persistent)"
+	}
+	return text
+}
+
+// slotsText is a helper function that lists the slots of the action with the
+// ability each sits beside.
+func slotsText(a ghec.Action) string {
+	abilities := strings.Split(a.Text, ",")
+	slots := make([]string, len(a.Slots))
+	for i, s := range a.Slots {
+		slots[i] = s.Type.String()
+		if s.Ability < len(abilities) {
+			slots[i] += fmt.Sprintf(" (%s)", strings.TrimSpace(abilities[s.Ability]))
+		}
+		if s.Filled {
+			slots[i] += fmt.Sprintf(": %s", ghec.Title(s.Enhancement))
+		}
+	}
+	return strings.Join(slots, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(cardsCmd)
+
+	cardsCmd.Flags().StringP("class", "c", "", "show only the cards of the class")
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// characterCmd represents the character command
+var characterCmd = &cobra.Command{
+	Use:   "character",
+	Short: "Keep track of characters, their gold, and their enhancements",
+	Long: `
+    Character keeps a ledger of characters with their class, their gold, and
+    the enhancements they have bought, so they last between runs. Each party
+    has a ledger, chosen with the --party flag or the party config key, which
+    the --storage flag or the storage config key keeps in YAML files (the
+    default), JSON files, or a single key/value file, under $HOME/.ghec. The
+    ledger keeps every change, so the undo and redo commands can reverse and
+    restore them, and the history command lists them.
+    `,
+}
+
+// loadLedger is a helper function that loads the ledger of the party from
+// the --party flag or the party config key.
+func loadLedger() (*ghec.Ledger, error) {
+	s, err := storage(viper.GetString("storage"))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return ghec.LoadParty(s, viper.GetString("party"))
+}
+
+// updateLedger is a helper function that loads the ledger of the party,
+// updates it with f, and saves it unless f fails.
+func updateLedger(f func(*ghec.Ledger) error) error {
+	s, err := storage(viper.GetString("storage"))
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return ghec.UpdateParty(s, viper.GetString("party"), f)
+}
+
+// character is a helper function that loads the ledger and returns the
+// named character.
+func character(name string) (*ghec.Character, error) {
+	l, err := loadLedger()
+	if err != nil {
+		return nil, err
+	}
+	return l.Character(name)
+}
+
+// printCharacter is a helper function that prints the character's gold, the
+// enhancements they have bought, and their enhanced cards.
+func printCharacter(c *ghec.Character) {
+	status := ""
+	if c.Retired {
+		status = ", retired"
+	}
+	if c.Level > 0 {
+		status += fmt.Sprintf(", level %d", c.Level)
+	}
+	fmt.Printf("%s (%s%s) has %d gold\n", c.Name, c.Class, status, c.Gold)
+	for _, p := range c.Enhancements {
+		fmt.Printf("  %s\n", p)
+	}
+	printCards(c.Cards)
+}
+
+// printCards is a helper function that prints the cards with their slots.
+func printCards(cards []ghec.AbilityCard) {
+	for _, card := range cards {
+		fmt.Printf("  %s: top %s; bottom %s\n", card.Name, slotsText(card.Top), slotsText(card.Bottom))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(characterCmd)
+}
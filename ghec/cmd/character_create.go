@@ -0,0 +1,56 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// characterCreateCmd represents the character create command
+var characterCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Add a character to the ledger",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		class, _ := cmd.Flags().GetString("class")
+		gold, _ := cmd.Flags().GetInt("gold")
+		cobra.CheckErr(updateLedger(func(l *ghec.Ledger) error {
+			c, err := l.Create(args[0], class, gold)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Created %s (%s) with %d gold\n", c.Name, c.Class, c.Gold)
+			return nil
+		}))
+	},
+}
+
+func init() {
+	characterCmd.AddCommand(characterCreateCmd)
+
+	characterCreateCmd.Flags().StringP("class", "c", "", "character class")
+	characterCreateCmd.Flags().Int("gold", 0, "starting gold")
+	cobra.CheckErr(characterCreateCmd.MarkFlagRequired("class"))
+}
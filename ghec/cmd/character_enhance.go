@@ -0,0 +1,132 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// characterEnhanceCmd represents the character enhance command
+var characterEnhanceCmd = &cobra.Command{
+	Use:   "enhance <name> <enhancement>",
+	Short: "Buy an enhancement with a character's gold",
+	Long: `
+    Enhance prices the enhancement with the same flags as the enhancement
+    commands, takes the cost from the character's gold, and records the
+    purchase in the ledger. It fails without changing the ledger if the
+    character cannot afford it. Use the --card flag to record the ability
+    card it goes on.
+
+    With the --half and --index flags as well, it enhances that slot of the
+    character's copy of the card, which starts from the cards their class
+    inherited from retired characters. The card sets the level, the action,
+    the slot type, and the previous enhancements, including the inherited
+    ones. Use --dry-run to see the price without buying.
+    `,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		be, err := baseEnhancement(args[1])
+		cobra.CheckErr(err)
+		card, _ := cmd.Flags().GetString("card")
+		half, _ := cmd.Flags().GetString("half")
+		index, _ := cmd.Flags().GetInt("index")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if half == "" {
+			if dryRun {
+				run(be, fmt.Sprintf("Add %s", ghec.Title(be)))
+				fmt.Println()
+				return
+			}
+			opts, err := options()
+			cobra.CheckErr(err)
+			cobra.CheckErr(updateLedger(func(l *ghec.Ledger) error {
+				cost, err := l.Enhance(args[0], be, card, opts...)
+				if err != nil {
+					return err
+				}
+				c, err := l.Character(args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s bought %s for %d and has %d gold left\n", c.Name, ghec.Title(be), cost, c.Gold)
+				return nil
+			}))
+			return
+		}
+		h, err := ghec.ParseHalf(half)
+		cobra.CheckErr(err)
+		db, err := cardDatabase()
+		cobra.CheckErr(err)
+		r, err := ruleset()
+		cobra.CheckErr(err)
+		opts := []ghec.Option{ghec.OptionWithRuleset(r), ghec.OptionWithEnhancerLevel(enhancer())}
+		if dryRun {
+			l, err := loadLedger()
+			cobra.CheckErr(err)
+			c, ac, err := characterCard(l, db, args[0], card)
+			cobra.CheckErr(err)
+			cost, err := ac.Price(h, index, be, opts...)
+			cobra.CheckErr(err)
+			fmt.Printf("%s on %s costs %d, and %s has %d gold\n", ghec.Title(be), ac.Name, cost, c.Name, c.Gold)
+			return
+		}
+		cobra.CheckErr(updateLedger(func(l *ghec.Ledger) error {
+			cost, err := l.EnhanceSlot(args[0], db, card, h, index, be, opts...)
+			if err != nil {
+				return err
+			}
+			c, ac, err := characterCard(l, db, args[0], card)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s bought %s on %s for %d and has %d gold left\n", c.Name, ghec.Title(be), ac.Name, cost, c.Gold)
+			return nil
+		}))
+	},
+}
+
+// characterCard is a helper function that returns the named character and
+// their copy of the named card.
+func characterCard(l *ghec.Ledger, db *ghec.CardDatabase, name, card string) (*ghec.Character, *ghec.AbilityCard, error) {
+	c, err := l.Character(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	ac, err := l.Card(c, db, card)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, ac, nil
+}
+
+func init() {
+	characterCmd.AddCommand(characterEnhanceCmd)
+
+	characterEnhanceCmd.Flags().String("card", "", "name of the ability card the enhancement goes on")
+	characterEnhanceCmd.Flags().String("half", "", "half of the card with the slot, top or bottom")
+	characterEnhanceCmd.Flags().Int("index", 0, "position of the slot on the half, counting from 0")
+	characterEnhanceCmd.Flags().Bool("dry-run", false, "show the price without buying")
+	characterEnhanceCmd.MarkFlagsRequiredTogether("half", "card")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// characterGoldCmd represents the character gold command
+var characterGoldCmd = &cobra.Command{
+	Use:   "gold",
+	Short: "Add or spend a character's gold",
+}
+
+// characterGoldAddCmd represents the character gold add command
+var characterGoldAddCmd = &cobra.Command{
+	Use:   "add <name> <gold>",
+	Short: "Add gold to a character",
+	Args:  cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		updateGold(args[0], args[1], (*ghec.Ledger).AddGold)
+	},
+}
+
+// characterGoldSpendCmd represents the character gold spend command
+var characterGoldSpendCmd = &cobra.Command{
+	Use:   "spend <name> <gold>",
+	Short: "Spend a character's gold",
+	Args:  cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		updateGold(args[0], args[1], (*ghec.Ledger).SpendGold)
+	},
+}
+
+// updateGold is a helper function that changes the named character's gold
+// with f and saves the ledger.
+func updateGold(name, amount string, f func(*ghec.Ledger, string, int) error) {
+	gold, err := strconv.Atoi(amount)
+	if err != nil {
+		cobra.CheckErr(fmt.Errorf("gold must be a number, not %q", amount))
+	}
+	cobra.CheckErr(updateLedger(func(l *ghec.Ledger) error {
+		if err := f(l, name, gold); err != nil {
+			return err
+		}
+		c, err := l.Character(name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s has %d gold\n", c.Name, c.Gold)
+		return nil
+	}))
+}
+
+func init() {
+	characterCmd.AddCommand(characterGoldCmd)
+	characterGoldCmd.AddCommand(characterGoldAddCmd)
+	characterGoldCmd.AddCommand(characterGoldSpendCmd)
+}
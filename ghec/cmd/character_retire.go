@@ -0,0 +1,59 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// characterRetireCmd represents the character retire command
+var characterRetireCmd = &cobra.Command{
+	Use:   "retire <name>",
+	Short: "Retire a character, leaving their enhanced cards to their class",
+	Long: `
+    Retire marks the character as retired and moves their enhanced cards to
+    their class, so the next character of the class inherits the
+    enhancements and pays for them as previous enhancements.
+    `,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		cobra.CheckErr(updateLedger(func(l *ghec.Ledger) error {
+			c, err := l.Character(args[0])
+			if err != nil {
+				return err
+			}
+			cards := len(c.Cards)
+			if err := l.Retire(c.Name); err != nil {
+				return err
+			}
+			fmt.Printf("%s retired and left %d enhanced cards to the %s class\n", c.Name, cards, c.Class)
+			return nil
+		}))
+	},
+}
+
+func init() {
+	characterCmd.AddCommand(characterRetireCmd)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// characterShowCmd represents the character show command
+var characterShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a character's gold and enhancements, or every character",
+	Long: `
+    Show prints a character's gold, the enhancements they have bought, and
+    their enhanced cards. Without a name, it prints every character and the
+    cards each class has inherited from its retired characters.
+    `,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) > 0 {
+			c, err := character(args[0])
+			cobra.CheckErr(err)
+			printCharacter(c)
+			return
+		}
+		l, err := loadLedger()
+		cobra.CheckErr(err)
+		for _, c := range l.Characters {
+			printCharacter(c)
+		}
+		for _, cc := range l.Classes {
+			fmt.Printf("%s class cards\n", cc.Class)
+			printCards(cc.Cards)
+		}
+	},
+}
+
+func init() {
+	characterCmd.AddCommand(characterShowCmd)
+}
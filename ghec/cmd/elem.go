@@ -22,6 +22,8 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/jluckyiv/ghec"
 	"github.com/spf13/cobra"
 )
@@ -33,14 +35,25 @@ var elemCmd = &cobra.Command{
 	Long: `
     Default behavior calculates cost for adding a specific element.
     Use the --any or -a flag for enhancement to add any element.
+    Use the --element flag to name the element, which sets the pricing:
+    wild is any element, and the others are specific elements.
     `,
 	Run: func(cmd *cobra.Command, _ []string) {
 		any, _ := cmd.Flags().GetBool("any")
+		name, _ := cmd.Flags().GetString("element")
+		el, err := ghec.ParseElement(name)
+		cobra.CheckErr(err)
+		if el != ghec.ElementNone && !cmd.Flags().Changed("any") {
+			any = el == ghec.ElementWild
+		}
+		be, desc := ghec.EnhanceSpecificElement, "Add specific element"
 		if any {
-			run(ghec.EnhanceAnyElement, "Add any element")
-		} else {
-			run(ghec.EnhanceSpecificElement, "Add specific element")
+			be, desc = ghec.EnhanceAnyElement, "Add any element"
+		}
+		if el != ghec.ElementNone {
+			desc = fmt.Sprintf("Add %s element", el)
 		}
+		run(be, desc, ghec.OptionWithElement(el))
 	},
 }
 
@@ -56,4 +69,5 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	elemCmd.Flags().BoolP("any", "a", false, "Any element enhancement")
+	elemCmd.Flags().StringP("element", "e", "", fmt.Sprintf("element to add, one of %v", ghec.Elements()))
 }
@@ -0,0 +1,60 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:   "explain <enhancement>",
+	Short: "Itemize the cost of an enhancement",
+	Long: `
+    Explain shows where the cost of an enhancement comes from, one line per
+    component, using the same flags as the enhancement subcommands.
+    The enhancement is its title in lower case, with hyphens for spaces,
+    for example "attack", "summons-hp" or "add-hex", or an alias such as
+    "hex" or "elem".
+    `,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: ghec.List(ghec.Slug),
+	Run: func(_ *cobra.Command, args []string) {
+		be, err := baseEnhancement(args[0])
+		cobra.CheckErr(err)
+		opts, err := options()
+		cobra.CheckErr(err)
+		items, err := ghec.NewEnhancement(be, opts...).Breakdown()
+		cobra.CheckErr(err)
+		for _, item := range items {
+			fmt.Printf("%-56s %+5d\n", item.Label, item.Cost)
+		}
+		fmt.Printf("%-56s %5d\n", "total", ghec.Total(items))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
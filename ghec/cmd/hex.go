@@ -22,6 +22,8 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/jluckyiv/ghec"
 	"github.com/spf13/cobra"
 )
@@ -30,14 +32,41 @@ import (
 var hexCmd = &cobra.Command{
 	Use:   "hex",
 	Short: "Add hex to an AoE attack",
-	Run: func(_ *cobra.Command, _ []string) {
-		run(ghec.EnhanceAddAttackHex, "Add hex")
+	Long: `
+    Hex prices adding a hex to an area of effect. Use the --pattern flag to
+    count the current hexes from the pattern instead of passing --hexes.
+    The pattern has a row per line, or rows separated by "/", with X for a
+    target hex, @ for the caster, + for the hex to add, and . for an empty
+    hex. Rows are indented half a hex from their neighbors, for example
+    " X X/X @ +". Axial coordinates work too, such as "@0,0 1,0 0,1 +1,1".
+    The caster's hex does not count, and the new hex must be next to a
+    target hex.
+    `,
+	Run: func(cmd *cobra.Command, _ []string) {
+		pattern, _ := cmd.Flags().GetString("pattern")
+		if pattern == "" {
+			run(ghec.EnhanceAddAttackHex, "Add hex")
+			return
+		}
+		aoe, err := ghec.ParseAreaOfEffect(pattern)
+		cobra.CheckErr(err)
+		if aoe.New != nil {
+			cobra.CheckErr(aoe.CanAdd(*aoe.New))
+		}
+		fmt.Printf("%s\n\n", aoe)
+		desc := fmt.Sprintf("Add hex to %d hexes", aoe.Hexes())
+		if aoe.Hexes() == 1 {
+			desc = "Add hex to 1 hex"
+		}
+		run(ghec.EnhanceAddAttackHex, desc, aoe.Options()...)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(hexCmd)
 
+	hexCmd.Flags().String("pattern", "", "area of effect pattern, such as \" X X/X @ +\"")
+
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
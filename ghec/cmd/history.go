@@ -0,0 +1,59 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the changes to the character ledger",
+	Long: `
+    History lists the changes in effect in the character ledger, oldest
+    first, with what each purchase cost in the game that priced it. The
+    changes that the redo command can restore follow.
+    `,
+	Args: cobra.NoArgs,
+	Run: func(_ *cobra.Command, _ []string) {
+		l, err := loadLedger()
+		cobra.CheckErr(err)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for i, ev := range l.History() {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", i+1, ev.Time.Local().Format("2006-01-02 15:04"), ev)
+		}
+		undone := l.Undone()
+		for i := len(undone) - 1; i >= 0; i-- {
+			fmt.Fprintf(w, "undone\t%s\t%s\n", undone[i].Time.Local().Format("2006-01-02 15:04"), undone[i])
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
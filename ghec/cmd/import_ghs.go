@@ -0,0 +1,75 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// importGHSCmd represents the import ghs command
+var importGHSCmd = &cobra.Command{
+	Use:   "ghs <file>",
+	Short: "Import the characters from a Gloomhaven Secretariat save file",
+	Long: `
+    GHS reads a Gloomhaven Secretariat backup or exported game and adds its
+    characters to the party's ledger, with their class, level, gold, and the
+    enhancements on their ability cards, which it finds in the card data by
+    their numbers. Retired characters leave their cards to their class.
+    Characters already in the ledger are skipped, and the enhancements it
+    cannot read or find a card for are listed as warnings. The undo command
+    reverses the import one character at a time.
+    `,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		db, err := cardDatabase()
+		cobra.CheckErr(err)
+		f, err := os.Open(args[0])
+		cobra.CheckErr(err)
+		defer f.Close()
+		characters, warnings, err := ghec.ParseGHS(f, db)
+		cobra.CheckErr(err)
+		for _, w := range warnings {
+			fmt.Fprintln(os.Stderr, "Warning:", w)
+		}
+		cobra.CheckErr(updateLedger(func(l *ghec.Ledger) error {
+			for _, c := range characters {
+				if _, err := l.Character(c.Name); err == nil {
+					fmt.Printf("Skipped %s, who is already in the ledger\n", c.Name)
+					continue
+				}
+				if err := l.Import(c, ghec.GHSSource); err != nil {
+					return err
+				}
+				fmt.Println(l.Events[len(l.Events)-1])
+			}
+			return nil
+		}))
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importGHSCmd)
+}
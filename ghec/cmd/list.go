@@ -0,0 +1,104 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// allCategories is the --category value that groups every category.
+const allCategories = "all"
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the enhancements of the game",
+	Long: `
+    List shows the enhancements the game allows with their base costs.
+    Use the --category flag to group them by category, or --category=<name>
+    to show a single category.
+    `,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		r, err := ruleset()
+		cobra.CheckErr(err)
+		if !cmd.Flags().Changed("category") {
+			printEnhancements(r, r.Enhancements())
+			return
+		}
+		category, _ := cmd.Flags().GetString("category")
+		categories := ghec.Categories()
+		if category != allCategories {
+			c, ok := ghec.ParseCategory(category)
+			if !ok {
+				cobra.CheckErr(fmt.Errorf("unknown category %q, must be one of %v", category, categorySlugs()))
+			}
+			categories = []ghec.EnhancementCategory{c}
+		}
+		printed := false
+		for _, c := range categories {
+			list := ghec.InCategory(c, r.Enhancements())
+			if len(list) == 0 {
+				continue
+			}
+			if printed {
+				fmt.Println()
+			}
+			fmt.Println(c)
+			printEnhancements(r, list)
+			printed = true
+		}
+	},
+}
+
+// printEnhancements is a helper function that prints a table of the base
+// enhancements with their command-line names and base costs in the ruleset.
+func printEnhancements(r ghec.Ruleset, list []ghec.BaseEnhancement) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, be := range list {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", ghec.Title(be), ghec.Slug(be), ghec.DescriptionFor(r, be))
+	}
+	w.Flush()
+}
+
+// categorySlugs is a helper function that returns the command-line names of
+// all the categories.
+func categorySlugs() []string {
+	categories := ghec.Categories()
+	slugs := make([]string, len(categories))
+	for i, c := range categories {
+		slugs[i] = c.Slug()
+	}
+	return slugs
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringP("category", "c", "", "group by category, or show only the named category")
+	listCmd.Flags().Lookup("category").NoOptDefVal = allCategories
+}
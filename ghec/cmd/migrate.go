@@ -0,0 +1,75 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate --to <storage>",
+	Short: "Copy the character ledgers to another storage",
+	Long: `
+    Migrate copies everything in one storage backend to another in the same
+    data directory, such as the YAML files to the single key/value file for
+    a large campaign. The --from flag defaults to the current storage, from
+    the --storage flag or the storage config key. It leaves the source as it
+    was, so set the storage config key to the new backend afterwards.
+    `,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		from, _ := cmd.Flags().GetString("from")
+		if from == "" {
+			from = viper.GetString("storage")
+		}
+		to, _ := cmd.Flags().GetString("to")
+		if strings.EqualFold(from, to) {
+			cobra.CheckErr(fmt.Errorf("cannot migrate %s storage to itself", from))
+		}
+		src, err := storage(from)
+		cobra.CheckErr(err)
+		defer src.Close()
+		dst, err := storage(to)
+		cobra.CheckErr(err)
+		defer dst.Close()
+		keys, err := ghec.Migrate(src, dst)
+		cobra.CheckErr(err)
+		for _, key := range keys {
+			fmt.Println(key)
+		}
+		fmt.Printf("Copied %d keys from %s to %s storage\n", len(keys), from, to)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().String("from", "", fmt.Sprintf("storage to copy from, one of %v (default is --storage)", ghec.StorageKinds()))
+	migrateCmd.Flags().String("to", "", fmt.Sprintf("storage to copy to, one of %v", ghec.StorageKinds()))
+	cobra.CheckErr(migrateCmd.MarkFlagRequired("to"))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2023 Jackson Lucky <jack@jacksonlucky.net>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jluckyiv/ghec"
+	"github.com/spf13/cobra"
+)
+
+// priceCmd represents the price command
+var priceCmd = &cobra.Command{
+	Use:   "price <enhancement>",
+	Short: "Price an enhancement on the ability text of a card",
+	Long: `
+    Price reads the action type, targets, area of effect, and lost or
+    persistent icons from the --ability text, such as "Attack 3, Target 3",
+    instead of the --targets, --hexes, --action, --side, --lost and
+    --persistent flags. The other flags still apply. The enhancement is
+    named as in the explain command. After the price, it shows the ability
+    text with the enhancement applied, with the --element flag naming the
+    element of an element enhancement.
+    `,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: ghec.List(ghec.Slug),
+	Run: func(cmd *cobra.Command, args []string) {
+		be, err := baseEnhancement(args[0])
+		cobra.CheckErr(err)
+		text, _ := cmd.Flags().GetString("ability")
+		ability, err := ghec.ParseAbility(text)
+		cobra.CheckErr(err)
+		opts, err := options()
+		cobra.CheckErr(err)
+		name, _ := cmd.Flags().GetString("element")
+		el, err := ghec.ParseElement(name)
+		cobra.CheckErr(err)
+		opts = append(append(opts, ability.Options()...), ghec.OptionWithElement(el))
+		cost, err := ghec.NewEnhancement(be, opts...).Cost()
+		cobra.CheckErr(err)
+		fmt.Printf("%s on %q costs %d\n", ghec.Title(be), text, cost)
+		preview, err := ghec.Render(ability, be)
+		if el != ghec.ElementNone {
+			preview, err = ghec.RenderElement(ability, el)
+		}
+		cobra.CheckErr(err)
+		fmt.Printf("%s becomes %s\n", ability, preview)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(priceCmd)
+
+	priceCmd.Flags().StringP("ability", "b", "", `ability text, such as "Attack 3, Target 3"`)
+	priceCmd.Flags().StringP("element", "e", "", fmt.Sprintf("element an element enhancement adds, one of %v", ghec.Elements()))
+	cobra.CheckErr(priceCmd.MarkFlagRequired("ability"))
+}
@@ -24,6 +24,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/jluckyiv/ghec"
 	"github.com/spf13/cobra"
@@ -33,8 +34,20 @@ import (
 var (
 	cfgFile              string
 	numTargets           int
+	numHexes             int
 	level                int
 	previousEnhancements int
+	game                 string
+	lostAction           bool
+	persistentAction     bool
+	enhancerLevel        int
+	slotType             string
+	actionType           string
+	side                 string
+	cardData             []string
+	storageKind          string
+	dataDirectory        string
+	party                string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -47,21 +60,125 @@ var rootCmd = &cobra.Command{
 	// },
 }
 
-// run is a helper function for the subcommands, which are similar.
-func run(be ghec.BaseEnhancement, desc string) {
-	l := ghec.Level(level)
-	pe := ghec.PreviousEnhancements(previousEnhancements)
-	e := ghec.
-		NewEnhancement(be,
-			ghec.OptionWithLevel(l),
-			ghec.OptionWithMultipleTarget(numTargets),
-			ghec.OptionWithPreviousEnhancements(ghec.PreviousEnhancements(pe)),
-		)
+// run is a helper function for the subcommands, which are similar. The extra
+// options apply after the ones for the persistent flags.
+func run(be ghec.BaseEnhancement, desc string, extra ...ghec.Option) {
+	opts, err := options()
+	cobra.CheckErr(err)
+	e := ghec.NewEnhancement(be, append(opts, extra...)...)
 	cost, err := e.Cost()
 	cobra.CheckErr(err)
 	fmt.Printf("%s costs %d", desc, cost)
 }
 
+// options is a helper function that returns the enhancement options for the
+// persistent flags.
+func options() ([]ghec.Option, error) {
+	r, err := ruleset()
+	if err != nil {
+		return nil, err
+	}
+	s, err := ghec.ParseSlot(slotType)
+	if err != nil {
+		return nil, err
+	}
+	a, err := ghec.ParseAction(actionType)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := ghec.ParseSide(side)
+	if err != nil {
+		return nil, err
+	}
+	l := ghec.Level(level)
+	pe := ghec.PreviousEnhancements(previousEnhancements)
+	return []ghec.Option{
+		ghec.OptionWithLevel(l),
+		ghec.OptionWithTargets(numTargets),
+		ghec.OptionWithHexes(hexes()),
+		ghec.OptionWithPreviousEnhancements(pe),
+		ghec.OptionWithRuleset(r),
+		ghec.OptionLostAction(lostAction),
+		ghec.OptionPersistentAction(persistentAction),
+		ghec.OptionWithEnhancerLevel(enhancer()),
+		ghec.OptionWithSlot(s),
+		ghec.OptionWithAction(a),
+		ghec.OptionWithSide(sd),
+	}, nil
+}
+
+// baseEnhancement is a helper function that returns the base enhancement with
+// the command-line name.
+func baseEnhancement(name string) (ghec.BaseEnhancement, error) {
+	be, ok := ghec.Parse(name)
+	if !ok {
+		return 0, fmt.Errorf("unknown enhancement %q, must be one of %v", name, ghec.List(ghec.Slug))
+	}
+	return be, nil
+}
+
+// hexes is a helper function that returns the number of current hexes.
+// Without the --hexes flag, it falls back to --targets, which used to set both.
+func hexes() int {
+	if !rootCmd.PersistentFlags().Changed("hexes") {
+		return numTargets
+	}
+	return numHexes
+}
+
+// ruleset is a helper function that returns the ruleset for the --game flag.
+func ruleset() (ghec.Ruleset, error) {
+	return ghec.RulesetByName(game)
+}
+
+// enhancer is a helper function that returns the Enhancer building level
+// from the --enhancer-level flag or the enhancer-level config key.
+func enhancer() ghec.EnhancerLevel {
+	return ghec.EnhancerLevel(viper.GetInt("enhancer-level"))
+}
+
+// cardDatabase is a helper function that returns the shipped ability cards
+// plus the data files from the --card-data flag or the card-data config key.
+func cardDatabase() (*ghec.CardDatabase, error) {
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range viper.GetStringSlice("card-data") {
+		if err := db.LoadPath(name); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// storage is a helper function that opens the storage backend of the kind in
+// the data directory.
+func storage(kind string) (ghec.Storage, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	return ghec.OpenStorage(kind, dir)
+}
+
+// dataDir is a helper function that returns the storage directory from the
+// --data-dir flag or the data-dir config key, or else the .ghec directory
+// beside the config file.
+func dataDir() (string, error) {
+	if dir := viper.GetString("data-dir"); dir != "" {
+		return dir, nil
+	}
+	if cfg := viper.ConfigFileUsed(); cfg != "" {
+		return filepath.Join(filepath.Dir(cfg), ".ghec"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ghec"), nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -78,8 +195,25 @@ func init() {
 	// will be global for your application.
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.ghec.yaml)")
 	rootCmd.PersistentFlags().IntVarP(&numTargets, "targets", "t", 1, "number of current targets")
+	rootCmd.PersistentFlags().IntVarP(&numHexes, "hexes", "x", 0, "number of current hexes in the area of effect (default is --targets)")
 	rootCmd.PersistentFlags().IntVarP(&level, "level", "l", 1, "ability card level")
 	rootCmd.PersistentFlags().IntVarP(&previousEnhancements, "previous", "p", 0, "number of previous enhancements")
+	rootCmd.PersistentFlags().StringVarP(&game, "game", "g", ghec.DefaultRuleset().Name(), fmt.Sprintf("game ruleset, one of %v", ghec.RulesetNames()))
+	rootCmd.PersistentFlags().BoolVar(&lostAction, "lost", false, "the action is a lost action")
+	rootCmd.PersistentFlags().BoolVar(&persistentAction, "persistent", false, "the action is a persistent action")
+	rootCmd.PersistentFlags().IntVar(&enhancerLevel, "enhancer-level", 1, "Enhancer building level (Frosthaven)")
+	rootCmd.PersistentFlags().StringVarP(&slotType, "slot", "s", "", fmt.Sprintf("slot type, one of %v", ghec.SlotTypes()))
+	rootCmd.PersistentFlags().StringVar(&actionType, "action", "", fmt.Sprintf("action type the enhancement goes on, one of %v", ghec.ActionTypes()))
+	rootCmd.PersistentFlags().StringVar(&side, "side", "", fmt.Sprintf("whom the action targets, one of %v", ghec.Sides()))
+	rootCmd.PersistentFlags().StringSliceVar(&cardData, "card-data", nil, "extra ability card data files or directories")
+	cobra.CheckErr(viper.BindPFlag("enhancer-level", rootCmd.PersistentFlags().Lookup("enhancer-level")))
+	rootCmd.PersistentFlags().StringVar(&storageKind, "storage", ghec.StorageYAML, fmt.Sprintf("storage for the character ledgers, one of %v", ghec.StorageKinds()))
+	rootCmd.PersistentFlags().StringVar(&dataDirectory, "data-dir", "", "directory of the storage (default is .ghec beside the config file)")
+	rootCmd.PersistentFlags().StringVar(&party, "party", "default", "party whose character ledger to use")
+	cobra.CheckErr(viper.BindPFlag("card-data", rootCmd.PersistentFlags().Lookup("card-data")))
+	cobra.CheckErr(viper.BindPFlag("storage", rootCmd.PersistentFlags().Lookup("storage")))
+	cobra.CheckErr(viper.BindPFlag("data-dir", rootCmd.PersistentFlags().Lookup("data-dir")))
+	cobra.CheckErr(viper.BindPFlag("party", rootCmd.PersistentFlags().Lookup("party")))
 }
 
 // initConfig reads in config file and ENV variables if set.
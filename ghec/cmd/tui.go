@@ -22,6 +22,7 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"github.com/jluckyiv/ghec"
 	"github.com/jluckyiv/ghec/tui"
 	"github.com/spf13/cobra"
 )
@@ -31,13 +32,23 @@ var tuiCmd = &cobra.Command{
 	Use:   "tui",
 	Short: "Run the TUI",
 	Run: func(cmd *cobra.Command, args []string) {
-		tui.Run()
+		r, err := ruleset()
+		cobra.CheckErr(err)
+		var ability *ghec.Ability
+		if text, _ := cmd.Flags().GetString("ability"); text != "" {
+			parsed, err := ghec.ParseAbility(text)
+			cobra.CheckErr(err)
+			ability = &parsed
+		}
+		tui.Run(r, enhancer(), ability)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(tuiCmd)
 
+	tuiCmd.Flags().StringP("ability", "b", "", `ability text to price and preview, such as "Attack 3, Range 2"`)
+
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
@@ -0,0 +1,240 @@
+package ghec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GHSSource is the source that characters imported from Gloomhaven
+// Secretariat record.
+const GHSSource = "Gloomhaven Secretariat"
+
+// ghsSave is the part of a Gloomhaven Secretariat save file that ghec reads.
+// A backup holds the game under a game key, and an exported game is the game
+// itself.
+type ghsSave struct {
+	Game *ghsGame `json:"game"`
+	ghsGame
+}
+
+// ghsGame is the game state in a Gloomhaven Secretariat save file.
+type ghsGame struct {
+	Characters []ghsCharacter `json:"characters"`
+}
+
+// ghsCharacter is a character in the game.
+type ghsCharacter struct {
+	// Name is the class, such as brute.
+	Name string `json:"name"`
+	// Title is the name the player gave the character, if any.
+	Title    string       `json:"title"`
+	Level    int          `json:"level"`
+	Progress *ghsProgress `json:"progress"`
+}
+
+// ghsProgress is the campaign progress of a character.
+type ghsProgress struct {
+	Gold         int              `json:"gold"`
+	Retired      bool             `json:"retired"`
+	Enhancements []ghsEnhancement `json:"enhancements"`
+}
+
+// ghsEnhancement is an enhancement on one of a character's ability cards.
+type ghsEnhancement struct {
+	// CardID is the number printed on the ability card.
+	CardID int `json:"cardId"`
+	// ActionIndex is the path to the action with the enhancement: the index
+	// of the action on the top half, or on the bottom half after "bottom",
+	// then the index of the action nested under it, if any, separated by
+	// dashes, such as "bottom-0-1".
+	ActionIndex string `json:"actionIndex"`
+	// Action is the enhancement, such as poison, fire, or plus1.
+	Action string `json:"action"`
+}
+
+// ParseGHS reads the characters from a Gloomhaven Secretariat save file: the
+// class, the name, the level, the gold, whether they retired, and the
+// enhancements on their ability cards. It looks the cards up in the card
+// database by class and number, for their names and levels and to tell what
+// a +1 raises, and applies the enhancements to the character's copies of
+// them. It returns a warning for each enhancement on a card the database does
+// not have, which it keeps under the card's number if it can, and for each
+// enhancement it cannot read, which it leaves out.
+func ParseGHS(r io.Reader, db *CardDatabase) ([]Character, []string, error) {
+	var save ghsSave
+	if err := json.NewDecoder(r).Decode(&save); err != nil {
+		return nil, nil, fmt.Errorf("reading Gloomhaven Secretariat save: %w", err)
+	}
+	game := save.ghsGame
+	if save.Game != nil {
+		game = *save.Game
+	}
+	var characters []Character
+	var warnings []string
+	for _, gc := range game.Characters {
+		if gc.Progress == nil || gc.Name == "" {
+			continue
+		}
+		c := Character{
+			Name:    gc.Title,
+			Class:   ghsClass(gc.Name),
+			Level:   gc.Level,
+			Gold:    gc.Progress.Gold,
+			Retired: gc.Progress.Retired,
+		}
+		if strings.TrimSpace(c.Name) == "" {
+			c.Name = c.Class
+		}
+		for _, e := range gc.Progress.Enhancements {
+			p, err := ghsPurchase(&c, db, e)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("%s, card %d: %v", c.Name, e.CardID, err))
+			}
+			if p != nil {
+				c.Enhancements = append(c.Enhancements, *p)
+			}
+		}
+		characters = append(characters, c)
+	}
+	return characters, warnings, nil
+}
+
+// ghsPurchase is a helper function that returns the purchase of the
+// enhancement and applies it to the character's copy of its card. It returns
+// the purchase with an error if the card is not in the database, and no
+// purchase if it cannot tell what the enhancement is.
+func ghsPurchase(c *Character, db *CardDatabase, e ghsEnhancement) (*Purchase, error) {
+	found, err := db.CardNumber(c.Class, e.CardID)
+	if err != nil {
+		if strings.EqualFold(e.Action, "plus1") {
+			return nil, fmt.Errorf("%w, so the ability plus1 raises is unknown", err)
+		}
+		be, _, beErr := ghsBaseEnhancement(e.Action)
+		if beErr != nil {
+			return nil, beErr
+		}
+		return &Purchase{Enhancement: be, Card: fmt.Sprintf("card %d", e.CardID)}, fmt.Errorf("%w, so its name and level are unknown", err)
+	}
+	card := ghsCard(c, found)
+	h, index, err := ghsActionIndex(e.ActionIndex)
+	if err != nil {
+		return nil, err
+	}
+	a, err := card.Action(h)
+	if err != nil {
+		return nil, err
+	}
+	ability, err := ParseAbility(a.Text)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(ability.Attributes) {
+		return nil, fmt.Errorf("%s has no action %s on its %s half", card.Name, e.ActionIndex, h)
+	}
+	var be BaseEnhancement
+	el := ElementNone
+	if strings.EqualFold(e.Action, "plus1") {
+		be, err = ghsPlusOne(ability.Attributes[index])
+	} else {
+		be, el, err = ghsBaseEnhancement(e.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := &Purchase{Enhancement: be, Card: card.Name, Level: card.Level}
+	for i, s := range a.Slots {
+		if s.Ability == index && !s.Filled && s.Type.Accepts(be) {
+			a.Slots[i].Filled = true
+			a.Slots[i].Enhancement = be
+			a.Slots[i].Element = el
+			return p, nil
+		}
+	}
+	return p, fmt.Errorf("%s has no empty slot for %s beside %q", card.Name, Title(be), ability.Attributes[index])
+}
+
+// ghsCard is a helper function that returns the character's copy of the card,
+// adding it to their cards if they have none.
+func ghsCard(c *Character, card AbilityCard) *AbilityCard {
+	for i := range c.Cards {
+		if strings.EqualFold(c.Cards[i].Name, card.Name) {
+			return &c.Cards[i]
+		}
+	}
+	c.Cards = append(c.Cards, card.clone())
+	return &c.Cards[len(c.Cards)-1]
+}
+
+// ghsActionIndex is a helper function that returns the half of the card and
+// the index of the ability in its text that the action index points to.
+// Gloomhaven Secretariat nests the abilities that modify an action, such as
+// Range under Attack, while ability text lists them after it, so the action
+// nested under the first action of a half is read as the ability after it.
+func ghsActionIndex(actionIndex string) (Half, int, error) {
+	parts := strings.Split(actionIndex, "-")
+	h := HalfTop
+	if strings.EqualFold(parts[0], HalfBottom.String()) {
+		h = HalfBottom
+		parts = parts[1:]
+	}
+	var path []int
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("unknown action index %q", actionIndex)
+		}
+		path = append(path, n)
+	}
+	switch len(path) {
+	case 1:
+		return h, path[0], nil
+	case 2:
+		return h, path[0] + 1 + path[1], nil
+	default:
+		return 0, 0, fmt.Errorf("unknown action index %q", actionIndex)
+	}
+}
+
+// ghsClass is a helper function that returns the class name for the
+// Gloomhaven Secretariat class, such as Brute for brute.
+func ghsClass(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == ' '
+	})
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// ghsPlusOne is a helper function that returns the base enhancement of a +1
+// on the ability, such as Attack for "Attack 3".
+func ghsPlusOne(attr Attribute) (BaseEnhancement, error) {
+	be, ok := Parse(attr.Name)
+	if ok {
+		if def, _ := lookup(be); numbered(def) && def.category != CategorySummons {
+			return be, nil
+		}
+	}
+	return 0, fmt.Errorf("plus1 cannot raise %q", attr)
+}
+
+// ghsBaseEnhancement is a helper function that returns the base enhancement
+// of a Gloomhaven Secretariat enhancement other than plus1, with the element
+// it infuses, if any.
+func ghsBaseEnhancement(action string) (BaseEnhancement, Element, error) {
+	name := strings.ToLower(action)
+	if el, err := ParseElement(name); err == nil && el != ElementNone {
+		if el == ElementWild {
+			return el.BaseEnhancement(), ElementNone, nil
+		}
+		return el.BaseEnhancement(), el, nil
+	}
+	if be, ok := Parse(name); ok {
+		return be, ElementNone, nil
+	}
+	return 0, ElementNone, fmt.Errorf("unknown enhancement %q", action)
+}
@@ -0,0 +1,122 @@
+package ghec_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+// parseGHS is a helper function that parses the named fixture with the
+// shipped cards.
+func parseGHS(t *testing.T, name string) ([]ghec.Character, []string, error) {
+	t.Helper()
+	db, err := ghec.DefaultCardDatabase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join("testdata", "ghs", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	return ghec.ParseGHS(f, db)
+}
+
+func TestParseGHSBackup(t *testing.T) {
+	characters, warnings, err := parseGHS(t, "backup.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(characters) != 2 {
+		t.Fatalf("expected the two characters and not the monster, got %+v", characters)
+	}
+	grok := characters[0]
+	if grok.Name != "Grok" || grok.Class != "Brute" || grok.Level != 3 || grok.Gold != 85 || grok.Retired {
+		t.Fatalf("unexpected character %+v", grok)
+	}
+	want := []ghec.Purchase{
+		{Enhancement: ghec.EnhanceAttack, Card: "Trample", Level: ghec.Level1},
+		{Enhancement: ghec.EnhanceMove, Card: "Trample", Level: ghec.Level1},
+		{Enhancement: ghec.EnhancePush, Card: "Warding Strength", Level: ghec.Level1},
+		{Enhancement: ghec.EnhancePoison, Card: "Spare Dagger", Level: ghec.Level1},
+		{Enhancement: ghec.EnhanceAddAttackHex, Card: "Sweeping Blow", Level: ghec.Level1},
+	}
+	if len(grok.Enhancements) != len(want) {
+		t.Fatalf("expected %d enhancements, got %+v", len(want), grok.Enhancements)
+	}
+	for i, p := range want {
+		if grok.Enhancements[i] != p {
+			t.Fatalf("expected %+v, got %+v", p, grok.Enhancements[i])
+		}
+	}
+	if len(grok.Cards) != 4 || grok.Cards[0].Name != "Trample" || grok.Cards[0].Previous(ghec.Gloomhaven1e{}, ghec.HalfTop) != 2 {
+		t.Fatalf("expected the enhancements applied to Grok's cards, got %+v", grok.Cards)
+	}
+	spellweaver := characters[1]
+	if spellweaver.Name != "Spellweaver" || !spellweaver.Retired || len(spellweaver.Enhancements) != 1 {
+		t.Fatalf("unexpected character %+v", spellweaver)
+	}
+	if p := spellweaver.Enhancements[0]; p.Enhancement != ghec.EnhanceAnyElement || p.Card != "card 62" || p.Level != 0 {
+		t.Fatalf("expected any element kept under the card number, got %+v", p)
+	}
+	if len(warnings) != 4 {
+		t.Fatalf("expected warnings for the unknown cards and the unknown enhancement, got %v", warnings)
+	}
+}
+
+func TestParseGHSGame(t *testing.T) {
+	characters, warnings, err := parseGHS(t, "game.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(characters) != 1 || len(warnings) != 2 {
+		t.Fatalf("expected one character with a warning for each card, got %+v, %v", characters, warnings)
+	}
+	c := characters[0]
+	if c.Name != "Flicker" || c.Class != "Blinkblade" || c.Gold != 40 || len(c.Enhancements) != 1 {
+		t.Fatalf("unexpected character %+v", c)
+	}
+	if c.Enhancements[0].Enhancement != ghec.EnhanceWard {
+		t.Fatalf("unexpected enhancements %+v", c.Enhancements)
+	}
+}
+
+func TestParseGHSInvalid(t *testing.T) {
+	if _, _, err := parseGHS(t, "truncated.json"); err == nil {
+		t.Fatal("expected an error for a truncated save file")
+	}
+}
+
+func TestLedgerImport(t *testing.T) {
+	characters, _, err := parseGHS(t, "backup.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := ghec.NewLedger()
+	for _, c := range characters {
+		if err := l.Import(c, ghec.GHSSource); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Import(characters[0], ghec.GHSSource); err == nil {
+		t.Fatal("expected an error for importing a character twice")
+	}
+	grok, err := l.Character("grok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grok.Gold != 85 || len(grok.Enhancements) != 5 || grok.Enhancements[0].Source != ghec.GHSSource {
+		t.Fatalf("unexpected character %+v", grok)
+	}
+	if characters[0].Enhancements[0].Source != "" {
+		t.Fatal("expected Import to leave the parsed character unchanged")
+	}
+	if _, err := l.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Character("Spellweaver"); err == nil {
+		t.Fatal("expected undo to remove the last import")
+	}
+}
@@ -0,0 +1,108 @@
+package ghec
+
+import "fmt"
+
+// Gloomhaven1e is the ruleset for Gloomhaven 1st edition.
+type Gloomhaven1e struct{}
+
+// Name is the name of the game, as accepted by RulesetByName.
+func (Gloomhaven1e) Name() string {
+	return "gloomhaven"
+}
+
+// gloomhaven1eCosts holds the base costs of the base enhancements that the
+// game allows, which the registry defines.
+var gloomhaven1eCosts = func() costTable {
+	t := costTable{}
+	for _, def := range registry {
+		if def.baseCost > 0 {
+			t[def.id] = def.baseCost
+		}
+	}
+	return t
+}()
+
+// Enhancements returns the base enhancements that the game allows, which are
+// all of them except the ones Frosthaven introduced.
+func (Gloomhaven1e) Enhancements() []BaseEnhancement {
+	return gloomhaven1eCosts.enhancements()
+}
+
+// BaseCost returns the base cost of the base enhancement.
+func (r Gloomhaven1e) BaseCost(be BaseEnhancement) (Cost, error) {
+	return gloomhaven1eCosts.baseCost(r, be)
+}
+
+// LevelCost returns the additional cost for the ability card level.
+func (Gloomhaven1e) LevelCost(level Level) (Cost, error) {
+	switch level {
+	case Level1:
+		return 0, nil
+	case Level2:
+		return 25, nil
+	case Level3:
+		return 50, nil
+	case Level4:
+		return 75, nil
+	case Level5:
+		return 100, nil
+	case Level6:
+		return 125, nil
+	case Level7:
+		return 150, nil
+	case Level8:
+		return 175, nil
+	case Level9:
+		return 200, nil
+	default:
+		return 0, fmt.Errorf("level must be between 1 and 9, not %d", level)
+	}
+}
+
+// PreviousCost returns the additional cost for the number of previous
+// enhancements, which is 75 gold for each. The printed table stops at 3, but
+// a card with more slots keeps adding 75 gold for each.
+func (Gloomhaven1e) PreviousCost(pe PreviousEnhancements) (Cost, error) {
+	if pe < PreviousEnhancements0 {
+		return 0, fmt.Errorf("previous enhancements must be at least 0, not %d", pe)
+	}
+	return Cost(75 * pe), nil
+}
+
+// PreviousScope counts every enhancement on the card as a previous
+// enhancement.
+func (Gloomhaven1e) PreviousScope() PreviousScope {
+	return ScopeCard
+}
+
+// DoublesForMultipleTargets reports whether the base cost doubles for
+// multiple targets, as the registry defines. Summons stats and Add Attack Hex
+// never double.
+func (Gloomhaven1e) DoublesForMultipleTargets(be BaseEnhancement) bool {
+	def, ok := lookup(be)
+	return ok && def.doubles
+}
+
+// AddHexCost returns 200 gold divided by the number of current hexes,
+// rounded down.
+func (r Gloomhaven1e) AddHexCost(hexes int) (Cost, error) {
+	return addHexCost(r, hexes)
+}
+
+// LostActionCost returns the cost unchanged, since lost actions do not affect
+// the cost in Gloomhaven.
+func (Gloomhaven1e) LostActionCost(_ BaseEnhancement, cost Cost) Cost {
+	return cost
+}
+
+// PersistentActionCost returns the cost unchanged, since persistent actions
+// do not affect the cost in Gloomhaven.
+func (Gloomhaven1e) PersistentActionCost(_ BaseEnhancement, cost Cost) Cost {
+	return cost
+}
+
+// EnhancerDiscount returns no discount, since Gloomhaven has no Enhancer
+// building.
+func (Gloomhaven1e) EnhancerDiscount(_ EnhancerLevel) (Discount, error) {
+	return Discount{}, nil
+}
@@ -0,0 +1,125 @@
+package ghec
+
+import "fmt"
+
+// Gloomhaven2e is the ruleset for Gloomhaven 2nd edition, which reworked
+// enhancement pricing along Frosthaven lines without the Enhancer building.
+// It keeps its own tables, so corrections to one game leave the other alone.
+type Gloomhaven2e struct{}
+
+// gloomhaven2eCosts holds the base costs of the base enhancements that the
+// game allows.
+var gloomhaven2eCosts = costTable{
+	EnhanceMove:            30,
+	EnhanceAttack:          50,
+	EnhanceRange:           30,
+	EnhanceShield:          80,
+	EnhancePush:            30,
+	EnhancePull:            20,
+	EnhancePierce:          30,
+	EnhanceRetaliate:       60,
+	EnhanceHeal:            30,
+	EnhanceTarget:          75,
+	EnhanceAddAttackHex:    200,
+	EnhancePoison:          50,
+	EnhanceWound:           75,
+	EnhanceMuddle:          40,
+	EnhanceImmobilize:      150,
+	EnhanceCurse:           150,
+	EnhanceStrengthen:      100,
+	EnhanceBless:           75,
+	EnhanceRegenerate:      40,
+	EnhanceWard:            75,
+	EnhanceJump:            60,
+	EnhanceSpecificElement: 100,
+	EnhanceAnyElement:      150,
+	EnhanceSummonsMove:     60,
+	EnhanceSummonsAttack:   100,
+	EnhanceSummonsRange:    50,
+	EnhanceSummonsHP:       40,
+}
+
+// Name is the name of the game, as accepted by RulesetByName.
+func (Gloomhaven2e) Name() string {
+	return "gloomhaven2e"
+}
+
+// Enhancements returns the base enhancements that the game allows, which are
+// all of them except disarm and teleport.
+func (Gloomhaven2e) Enhancements() []BaseEnhancement {
+	return gloomhaven2eCosts.enhancements()
+}
+
+// BaseCost returns the base cost of the base enhancement.
+func (r Gloomhaven2e) BaseCost(be BaseEnhancement) (Cost, error) {
+	return gloomhaven2eCosts.baseCost(r, be)
+}
+
+// LevelCost returns the additional cost for the ability card level, which is
+// 25 gold for each level above 1.
+func (Gloomhaven2e) LevelCost(level Level) (Cost, error) {
+	if level < Level1 || level > Level9 {
+		return 0, fmt.Errorf("level must be between 1 and 9, not %d", level)
+	}
+	return Cost(25 * (level - 1)), nil
+}
+
+// PreviousCost returns the additional cost for the number of previous
+// enhancements, which is 75 gold for each.
+func (Gloomhaven2e) PreviousCost(pe PreviousEnhancements) (Cost, error) {
+	if pe < PreviousEnhancements0 {
+		return 0, fmt.Errorf("previous enhancements must be at least 0, not %d", pe)
+	}
+	return Cost(75 * pe), nil
+}
+
+// PreviousScope counts only the enhancements on the same action as previous
+// enhancements.
+func (Gloomhaven2e) PreviousScope() PreviousScope {
+	return ScopeAction
+}
+
+// DoublesForMultipleTargets reports whether the base cost doubles for
+// multiple targets. Target, Add Attack Hex, elements, and summons stats never
+// double.
+func (Gloomhaven2e) DoublesForMultipleTargets(be BaseEnhancement) bool {
+	switch be {
+	case EnhanceTarget, EnhanceAddAttackHex:
+		return false
+	case EnhanceSpecificElement, EnhanceAnyElement:
+		return false
+	case EnhanceSummonsMove, EnhanceSummonsAttack, EnhanceSummonsRange, EnhanceSummonsHP:
+		return false
+	default:
+		return true
+	}
+}
+
+// AddHexCost returns 200 gold divided by the number of current hexes,
+// rounded down.
+func (r Gloomhaven2e) AddHexCost(hexes int) (Cost, error) {
+	return addHexCost(r, hexes)
+}
+
+// LostActionCost halves the cost of an enhancement on a lost action,
+// rounded down.
+func (Gloomhaven2e) LostActionCost(_ BaseEnhancement, cost Cost) Cost {
+	return cost / 2
+}
+
+// PersistentActionCost triples the cost of an enhancement on a persistent
+// action, except for summons stats.
+func (Gloomhaven2e) PersistentActionCost(be BaseEnhancement, cost Cost) Cost {
+	switch be {
+	case EnhanceSummonsMove, EnhanceSummonsAttack, EnhanceSummonsRange, EnhanceSummonsHP:
+		return cost
+	default:
+		return cost * 3
+	}
+}
+
+// EnhancerDiscount returns no discount, since Gloomhaven 2nd edition has no
+// Enhancer building to upgrade.
+func (Gloomhaven2e) EnhancerDiscount(_ EnhancerLevel) (Discount, error) {
+	return Discount{}, nil
+}
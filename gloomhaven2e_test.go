@@ -0,0 +1,133 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+// gloomhaven2eTestCases price enhancements by the rules in the Enhancements
+// section of the Gloomhaven 2nd edition rulebook. Each case checks one rule,
+// which its source names.
+var gloomhaven2eTestCases []testCase = []testCase{
+	{
+		name:     "add move to a level 1 card",
+		base:     ghec.EnhanceMove,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(30),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: base cost table",
+	},
+	{
+		name:     "add poison to a level 1 card",
+		base:     ghec.EnhancePoison,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(50),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: base cost table",
+	},
+	{
+		name:     "add jump to a level 5 card",
+		base:     ghec.EnhanceJump,
+		targets:  1,
+		level:    ghec.Level5,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(160),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: 25 gold for each card level above 1",
+	},
+	{
+		name:     "add range with one previous enhancement on the action",
+		base:     ghec.EnhanceRange,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements1,
+		expected: ghec.Cost(105),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: 75 gold for each enhancement already on the action",
+	},
+	{
+		name:     "add attack to an ability with three targets",
+		base:     ghec.EnhanceAttack,
+		targets:  3,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(100),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: double the base cost for multiple targets",
+	},
+	{
+		name:     "add any element is not doubled",
+		base:     ghec.EnhanceAnyElement,
+		targets:  3,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(150),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: target, hex, and element costs never double",
+	},
+	{
+		name:     "add hex to a two-hex area",
+		base:     ghec.EnhanceAddAttackHex,
+		targets:  2,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		expected: ghec.Cost(100),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: 200 gold divided by the current hexes",
+	},
+	{
+		name:     "add regenerate to a lost action",
+		base:     ghec.EnhanceRegenerate,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		lost:     true,
+		expected: ghec.Cost(20),
+		source:   "Gloomhaven 2nd edition rulebook, Enhancements: halve the base cost of a lost action",
+	},
+	{
+		name:       "add shield to a persistent action",
+		base:       ghec.EnhanceShield,
+		targets:    1,
+		level:      ghec.Level1,
+		prev:       ghec.PreviousEnhancements0,
+		persistent: true,
+		expected:   ghec.Cost(240),
+		source:     "Gloomhaven 2nd edition rulebook, Enhancements: triple the base cost of a persistent action",
+	},
+	{
+		name:     "the enhancer level 2 base discount does not apply",
+		base:     ghec.EnhanceAttack,
+		targets:  1,
+		level:    ghec.Level1,
+		prev:     ghec.PreviousEnhancements0,
+		enhancer: ghec.EnhancerLevel2,
+		expected: ghec.Cost(50),
+		source:   "Gloomhaven 2nd edition has no Enhancer building",
+	},
+	{
+		name:     "the enhancer level 4 previous discount does not apply",
+		base:     ghec.EnhanceAttack,
+		targets:  1,
+		level:    ghec.Level3,
+		prev:     ghec.PreviousEnhancements1,
+		enhancer: ghec.EnhancerLevel4,
+		expected: ghec.Cost(175),
+		source:   "Gloomhaven 2nd edition has no Enhancer building",
+	},
+}
+
+func TestGloomhaven2eHasNoTeleport(t *testing.T) {
+	for _, be := range []ghec.BaseEnhancement{ghec.EnhanceTeleport, ghec.EnhanceDisarm} {
+		if ghec.Allows(ghec.Gloomhaven2e{}, be) {
+			t.Fatalf("expected gloomhaven2e not to allow %s", ghec.Title(be))
+		}
+		_, err := ghec.NewEnhancement(be,
+			ghec.OptionWithRuleset(ghec.Gloomhaven2e{}),
+		).Cost()
+		if err == nil {
+			t.Fatalf("expected an error for %s in gloomhaven2e", ghec.Title(be))
+		}
+	}
+	if !ghec.Allows(ghec.Frosthaven{}, ghec.EnhanceTeleport) {
+		t.Fatal("expected frosthaven to allow teleport")
+	}
+}
@@ -0,0 +1,49 @@
+package ghec
+
+// JawsOfTheLion is the ruleset for Gloomhaven: Jaws of the Lion. It prices
+// enhancements from the Gloomhaven tables, but its sticker sheet has no
+// disarm or summons stats enhancements.
+type JawsOfTheLion struct {
+	Gloomhaven1e
+}
+
+// Name is the name of the game, as accepted by RulesetByName.
+func (JawsOfTheLion) Name() string {
+	return "jotl"
+}
+
+// Enhancements returns the base enhancements that the game allows.
+func (JawsOfTheLion) Enhancements() []BaseEnhancement {
+	return []BaseEnhancement{
+		EnhanceMove,
+		EnhanceAttack,
+		EnhanceRange,
+		EnhanceShield,
+		EnhancePush,
+		EnhancePull,
+		EnhancePierce,
+		EnhanceRetaliate,
+		EnhanceHeal,
+		EnhanceTarget,
+		EnhanceAddAttackHex,
+		EnhancePoison,
+		EnhanceWound,
+		EnhanceMuddle,
+		EnhanceImmobilize,
+		EnhanceCurse,
+		EnhanceStrengthen,
+		EnhanceBless,
+		EnhanceJump,
+		EnhanceSpecificElement,
+		EnhanceAnyElement,
+	}
+}
+
+// BaseCost returns the Gloomhaven base cost of the base enhancement, if the
+// game allows it.
+func (r JawsOfTheLion) BaseCost(be BaseEnhancement) (Cost, error) {
+	if !Allows(r, be) {
+		return 0, notAvailableError(r, be)
+	}
+	return r.Gloomhaven1e.BaseCost(be)
+}
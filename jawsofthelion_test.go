@@ -0,0 +1,24 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestJawsOfTheLionRejectsDisarm(t *testing.T) {
+	_, err := ghec.NewEnhancement(ghec.EnhanceDisarm,
+		ghec.OptionWithRuleset(ghec.JawsOfTheLion{}),
+	).Cost()
+	if err == nil {
+		t.Fatal("expected an error for disarm in jaws of the lion")
+	}
+	actual, err := ghec.NewEnhancement(ghec.EnhanceAttack,
+		ghec.OptionWithRuleset(ghec.JawsOfTheLion{}),
+		ghec.OptionWithMultipleTarget(3),
+		ghec.OptionWithLevel(ghec.Level3),
+	).Cost()
+	if err != nil || actual != 150 {
+		t.Fatalf("expected 150, got %d, %v", actual, err)
+	}
+}
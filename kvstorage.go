@@ -0,0 +1,261 @@
+package ghec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"math"
+	"os"
+)
+
+// kvMagic starts every key/value file, to tell it from other files.
+const kvMagic = "ghec-kv 1\n"
+
+// kvTombstone is the value length of a record that deletes its key.
+const kvTombstone = math.MaxUint32
+
+// kvCompactSize is the smallest file that the key/value storage compacts.
+const kvCompactSize = 64 << 10
+
+// kvStorage is a Storage that keeps every key in a single file, for
+// campaigns too large to keep in files of their own. The file is a log of
+// records: each change appends a record with the key and its new value, and
+// the latest record for a key wins. A record is the lengths of the key and
+// the value, the key, the value, and a checksum, so a record cut short by an
+// interrupted write is ignored. When most of the file is old values, it is
+// rewritten with only the latest ones.
+type kvStorage struct {
+	// name is the name of the file.
+	name string
+}
+
+// NewKVStorage returns a Storage that keeps every key in the named file,
+// creating it on the first change. A lock file beside it, with .lock added
+// to the name, guards it.
+func NewKVStorage(name string) (Storage, error) {
+	if _, _, err := kvRead(name); err != nil {
+		return nil, err
+	}
+	return &kvStorage{name: name}, nil
+}
+
+func (s *kvStorage) Load(key string, v any) error {
+	unlock, err := s.lock(key, false)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	values, _, err := kvRead(s.name)
+	if err != nil {
+		return err
+	}
+	return kvDecode(values, key, v)
+}
+
+func (s *kvStorage) Save(key string, v any) error {
+	unlock, err := s.lock(key, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	values, end, err := kvRead(s.name)
+	if err != nil {
+		return err
+	}
+	return s.put(values, end, key, v)
+}
+
+func (s *kvStorage) Update(key string, v any, f func() error) error {
+	unlock, err := s.lock(key, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	values, end, err := kvRead(s.name)
+	if err != nil {
+		return err
+	}
+	if err := kvDecode(values, key, v); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if err := f(); err != nil {
+		return err
+	}
+	return s.put(values, end, key, v)
+}
+
+func (s *kvStorage) Delete(key string) error {
+	unlock, err := s.lock(key, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	values, end, err := kvRead(s.name)
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return fmt.Errorf("%s: %w", key, ErrNotFound)
+	}
+	delete(values, key)
+	return s.append(values, end, kvRecord(key, nil))
+}
+
+func (s *kvStorage) Keys() ([]string, error) {
+	unlock, err := lockPath(s.name+".lock", false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	values, _, err := kvRead(s.name)
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(values), nil
+}
+
+func (s *kvStorage) Close() error {
+	return nil
+}
+
+// lock is a helper method that checks the key and locks the file.
+func (s *kvStorage) lock(key string, exclusive bool) (func() error, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	return lockPath(s.name+".lock", exclusive)
+}
+
+// put is a helper method that appends a record with v as the value of the
+// key to the file, whose valid records hold the values and end at the offset.
+func (s *kvStorage) put(values map[string][]byte, end int64, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	values[key] = data
+	return s.append(values, end, kvRecord(key, data))
+}
+
+// append is a helper method that appends the record after the valid records,
+// dropping any record cut short after them. It compacts the file instead
+// when most of it would be old values.
+func (s *kvStorage) append(values map[string][]byte, end int64, record []byte) error {
+	live := int64(len(kvMagic))
+	for key, data := range values {
+		live += int64(len(kvRecord(key, data)))
+	}
+	if size := end + int64(len(record)); size > kvCompactSize && size > 2*live {
+		return s.compact(values)
+	}
+	f, err := os.OpenFile(s.name, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if end == 0 {
+		record = append([]byte(kvMagic), record...)
+	}
+	err = f.Truncate(end)
+	if err == nil {
+		_, err = f.WriteAt(record, end)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	return errors.Join(err, f.Close())
+}
+
+// compact is a helper method that rewrites the file with only the values.
+func (s *kvStorage) compact(values map[string][]byte) error {
+	var buf bytes.Buffer
+	buf.WriteString(kvMagic)
+	for _, key := range sortedKeys(values) {
+		buf.Write(kvRecord(key, values[key]))
+	}
+	return writeFileAtomic(s.name, buf.Bytes())
+}
+
+// kvRead is a helper function that reads the named file and returns the
+// latest value of each key and the offset where the valid records end. A
+// missing file has no values.
+func kvRead(name string) (map[string][]byte, int64, error) {
+	values := make(map[string][]byte)
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) || len(data) == 0 {
+		return values, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if !bytes.HasPrefix(data, []byte(kvMagic)) {
+		return nil, 0, fmt.Errorf("%s is not a ghec key/value file", name)
+	}
+	off := len(kvMagic)
+	for {
+		key, value, n := kvParse(data[off:])
+		if n == 0 {
+			return values, int64(off), nil
+		}
+		if value == nil {
+			delete(values, key)
+		} else {
+			values[key] = value
+		}
+		off += n
+	}
+}
+
+// kvDecode is a helper function that decodes the value of the key into v.
+func kvDecode(values map[string][]byte, key string, v any) error {
+	data, ok := values[key]
+	if !ok {
+		return fmt.Errorf("%s: %w", key, ErrNotFound)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// kvRecord is a helper function that returns the record of the key and the
+// value. A nil value deletes the key.
+func kvRecord(key string, value []byte) []byte {
+	record := binary.LittleEndian.AppendUint32(nil, uint32(len(key)))
+	if value == nil {
+		record = binary.LittleEndian.AppendUint32(record, kvTombstone)
+	} else {
+		record = binary.LittleEndian.AppendUint32(record, uint32(len(value)))
+	}
+	record = append(record, key...)
+	record = append(record, value...)
+	return binary.LittleEndian.AppendUint32(record, crc32.ChecksumIEEE(record))
+}
+
+// kvParse is a helper function that parses the record at the start of the
+// data and returns its key, its value, which is nil if it deletes the key,
+// and its length. The length is 0 if the data does not start with a whole
+// record.
+func kvParse(data []byte) (string, []byte, int) {
+	if len(data) < 8 {
+		return "", nil, 0
+	}
+	keyLen := int(binary.LittleEndian.Uint32(data))
+	valueLen := binary.LittleEndian.Uint32(data[4:])
+	deleted := valueLen == kvTombstone
+	if deleted {
+		valueLen = 0
+	}
+	n := 8 + keyLen + int(valueLen)
+	if keyLen > len(data) || int(valueLen) > len(data) || n+4 > len(data) {
+		return "", nil, 0
+	}
+	if crc32.ChecksumIEEE(data[:n]) != binary.LittleEndian.Uint32(data[n:]) {
+		return "", nil, 0
+	}
+	key := string(data[8 : 8+keyLen])
+	if deleted {
+		return key, nil, n + 4
+	}
+	return key, append([]byte{}, data[8+keyLen:n]...), n + 4
+}
@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package ghec
+
+import "os"
+
+// lockFile does nothing on systems without file locks, where the storage
+// relies on atomic writes alone.
+func lockFile(f *os.File, exclusive bool) error {
+	return nil
+}
+
+// unlockFile does nothing on systems without file locks.
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,224 @@
+package ghec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnhancementCategory is an enum of the groups that players sort the base
+// enhancements into.
+type EnhancementCategory int
+
+// Category* are constants for all the enhancement categories, exported for
+// type safety.
+const (
+	CategoryNumeric EnhancementCategory = iota
+	CategoryNegativeCondition
+	CategoryPositiveCondition
+	CategoryMovement
+	CategoryElement
+	CategorySummons
+	CategoryArea
+)
+
+// Categories returns all the enhancement categories, in display order.
+func Categories() []EnhancementCategory {
+	return []EnhancementCategory{
+		CategoryNumeric,
+		CategoryNegativeCondition,
+		CategoryPositiveCondition,
+		CategoryMovement,
+		CategoryElement,
+		CategorySummons,
+		CategoryArea,
+	}
+}
+
+// String returns the heading of the category.
+func (c EnhancementCategory) String() string {
+	switch c {
+	case CategoryNumeric:
+		return "Numeric +1"
+	case CategoryNegativeCondition:
+		return "Negative conditions"
+	case CategoryPositiveCondition:
+		return "Positive conditions"
+	case CategoryMovement:
+		return "Movement effects"
+	case CategoryElement:
+		return "Elements"
+	case CategorySummons:
+		return "Summons stats"
+	case CategoryArea:
+		return "Area hexes"
+	default:
+		return "Unknown"
+	}
+}
+
+// Slug returns the command-line name of the category.
+func (c EnhancementCategory) Slug() string {
+	switch c {
+	case CategoryNumeric:
+		return "numeric"
+	case CategoryNegativeCondition:
+		return "negative"
+	case CategoryPositiveCondition:
+		return "positive"
+	case CategoryMovement:
+		return "movement"
+	case CategoryElement:
+		return "element"
+	case CategorySummons:
+		return "summons"
+	case CategoryArea:
+		return "area"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseCategory returns the category with the given slug.
+func ParseCategory(name string) (EnhancementCategory, bool) {
+	for _, c := range Categories() {
+		if c.Slug() == strings.ToLower(name) {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// Category returns the category of the base enhancement.
+func Category(be BaseEnhancement) EnhancementCategory {
+	def, ok := lookup(be)
+	if !ok {
+		return -1
+	}
+	return def.category
+}
+
+// InCategory returns the base enhancements in the list that belong to the
+// category, in the same order.
+func InCategory(c EnhancementCategory, list []BaseEnhancement) []BaseEnhancement {
+	var in []BaseEnhancement
+	for _, be := range list {
+		if Category(be) == c {
+			in = append(in, be)
+		}
+	}
+	return in
+}
+
+// definition describes a base enhancement. Every function that needs to know
+// something about a base enhancement reads it from the registry, so adding a
+// base enhancement is a matter of adding a definition.
+type definition struct {
+	// id is the base enhancement the definition describes.
+	id BaseEnhancement
+	// title is the name of the base enhancement.
+	title string
+	// description is the effect of the base enhancement, without its cost.
+	description string
+	// baseCost is the Gloomhaven 1st edition base cost. It is zero for base
+	// enhancements that Gloomhaven does not have.
+	baseCost Cost
+	// category is the group the base enhancement belongs to.
+	category EnhancementCategory
+	// aliases are the names the CLI accepts for the base enhancement, besides
+	// its title in lower case with hyphens for spaces.
+	aliases []string
+	// doubles is whether the base cost doubles for multiple targets in
+	// Gloomhaven 1st edition.
+	doubles bool
+	// slots are the slot types that accept the base enhancement.
+	slots []SlotType
+	// actions are the action types the base enhancement goes on. It is nil
+	// for base enhancements that go on any action.
+	actions []ActionType
+}
+
+// registry holds the definitions of all the base enhancements, in the order
+// of the BaseEnhancement constants.
+var registry = []definition{
+	{id: EnhanceMove, title: "Move", description: "enhance +1 move", baseCost: 30, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: moveActions},
+	{id: EnhanceAttack, title: "Attack", description: "enhance +1 attack", baseCost: 50, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: attackActions},
+	{id: EnhanceRange, title: "Range", description: "enhance +1 range", baseCost: 30, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: rangedActions},
+	{id: EnhanceShield, title: "Shield", description: "enhance +1 shield", baseCost: 100, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: shieldActions},
+	{id: EnhancePush, title: "Push", description: "enhance +1 push", baseCost: 30, category: CategoryMovement, doubles: true, slots: plusOneSlots, actions: forcedActions},
+	{id: EnhancePull, title: "Pull", description: "enhance +1 pull", baseCost: 30, category: CategoryMovement, doubles: true, slots: plusOneSlots, actions: forcedActions},
+	{id: EnhancePierce, title: "Pierce", description: "enhance +1 pierce", baseCost: 30, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: attackActions},
+	{id: EnhanceRetaliate, title: "Retaliate", description: "enhance +1 retaliate", baseCost: 100, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: retaliateActions},
+	{id: EnhanceHeal, title: "Heal", description: "enhance +1 heal", baseCost: 30, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: healActions},
+	{id: EnhanceTarget, title: "Target", description: "enhance +1 target", baseCost: 50, category: CategoryNumeric, doubles: true, slots: plusOneSlots, actions: rangedActions},
+	{id: EnhanceSummonsMove, title: "Summons Move", description: "enhance summons +1 move", baseCost: 100, category: CategorySummons, slots: plusOneSlots, actions: summonActions},
+	{id: EnhanceSummonsAttack, title: "Summons Attack", description: "enhance summons +1 attack", baseCost: 100, category: CategorySummons, slots: plusOneSlots, actions: summonActions},
+	{id: EnhanceSummonsRange, title: "Summons Range", description: "enhance summons +1 range", baseCost: 50, category: CategorySummons, slots: plusOneSlots, actions: summonActions},
+	{id: EnhanceSummonsHP, title: "Summons HP", description: "enhance summons +1 HP", baseCost: 50, category: CategorySummons, slots: plusOneSlots, actions: summonActions},
+	{id: EnhanceAddAttackHex, title: "Add Hex", description: "add attack hex", baseCost: 200, category: CategoryArea, aliases: []string{"hex"}, slots: hexSlots, actions: attackActions},
+	{id: EnhancePoison, title: "Poison", description: "add poison effect", baseCost: 75, category: CategoryNegativeCondition, doubles: true, slots: negativeConditionSlots},
+	{id: EnhanceWound, title: "Wound", description: "add wound effect", baseCost: 75, category: CategoryNegativeCondition, doubles: true, slots: negativeConditionSlots},
+	{id: EnhanceMuddle, title: "Muddle", description: "add muddle effect", baseCost: 50, category: CategoryNegativeCondition, doubles: true, slots: negativeConditionSlots},
+	{id: EnhanceImmobilize, title: "Immobilize", description: "add immobilize effect", baseCost: 100, category: CategoryNegativeCondition, doubles: true, slots: negativeConditionSlots},
+	{id: EnhanceDisarm, title: "Disarm", description: "add disarm effect", baseCost: 150, category: CategoryNegativeCondition, doubles: true, slots: negativeConditionSlots},
+	{id: EnhanceCurse, title: "Curse", description: "add curse effect", baseCost: 75, category: CategoryNegativeCondition, doubles: true, slots: negativeConditionSlots},
+	{id: EnhanceStrengthen, title: "Strengthen", description: "add strengthen effect", baseCost: 50, category: CategoryPositiveCondition, doubles: true, slots: positiveConditionSlots},
+	{id: EnhanceBless, title: "Bless", description: "add bless effect", baseCost: 50, category: CategoryPositiveCondition, doubles: true, slots: positiveConditionSlots},
+	{id: EnhanceJump, title: "Jump", description: "add jump effect", baseCost: 50, category: CategoryMovement, doubles: true, slots: effectSlots, actions: moveActions},
+	{id: EnhanceSpecificElement, title: "Specific Element", description: "add effect: specific element", baseCost: 100, category: CategoryElement, aliases: []string{"elem", "element"}, doubles: true, slots: effectSlots},
+	{id: EnhanceAnyElement, title: "Any Element", description: "add effect: any element", baseCost: 150, category: CategoryElement, aliases: []string{"any-elem", "wild-element"}, doubles: true, slots: effectSlots},
+	{id: EnhanceTeleport, title: "Teleport", description: "enhance +1 teleport", category: CategoryMovement, doubles: true, slots: plusOneSlots, actions: teleportActions},
+	{id: EnhanceRegenerate, title: "Regenerate", description: "add regenerate effect", category: CategoryPositiveCondition, doubles: true, slots: positiveConditionSlots},
+	{id: EnhanceWard, title: "Ward", description: "add ward effect", category: CategoryPositiveCondition, doubles: true, slots: positiveConditionSlots},
+}
+
+// lookup is a helper function that returns the definition of the base
+// enhancement.
+func lookup(be BaseEnhancement) (definition, bool) {
+	for _, def := range registry {
+		if def.id == be {
+			return def, true
+		}
+	}
+	return definition{}, false
+}
+
+// Slug returns the command-line name of the base enhancement, which is its
+// title in lower case with hyphens for spaces.
+func Slug(be BaseEnhancement) string {
+	return strings.ReplaceAll(strings.ToLower(Title(be)), " ", "-")
+}
+
+// Parse returns the base enhancement with the given slug or alias.
+func Parse(name string) (BaseEnhancement, bool) {
+	name = strings.ToLower(name)
+	for _, def := range registry {
+		if Slug(def.id) == name {
+			return def.id, true
+		}
+		for _, alias := range def.aliases {
+			if alias == name {
+				return def.id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// MarshalText encodes the base enhancement as its slug, for data files.
+func (be BaseEnhancement) MarshalText() ([]byte, error) {
+	if _, ok := lookup(be); !ok {
+		return nil, fmt.Errorf("unknown base enhancement %d", be)
+	}
+	return []byte(Slug(be)), nil
+}
+
+// UnmarshalText decodes the base enhancement from its slug or alias, for data
+// files.
+func (be *BaseEnhancement) UnmarshalText(text []byte) error {
+	parsed, ok := Parse(string(text))
+	if !ok {
+		return fmt.Errorf("unknown enhancement %q", text)
+	}
+	*be = parsed
+	return nil
+}
@@ -0,0 +1,29 @@
+package ghec
+
+import "testing"
+
+func TestEveryBaseEnhancementIsRegistered(t *testing.T) {
+	if len(registry) != int(numBaseEnhancements) {
+		t.Fatalf("expected %d definitions, got %d", numBaseEnhancements, len(registry))
+	}
+	for be := BaseEnhancement(0); be < numBaseEnhancements; be++ {
+		if registry[be].id != be {
+			t.Fatalf("expected definition %d to be for %d, got %d", be, be, registry[be].id)
+		}
+	}
+}
+
+func TestSlugsAndAliasesAreUnique(t *testing.T) {
+	seen := map[string]BaseEnhancement{}
+	for _, def := range registry {
+		for _, name := range append([]string{Slug(def.id)}, def.aliases...) {
+			if other, ok := seen[name]; ok {
+				t.Fatalf("%q names both %s and %s", name, Title(other), def.title)
+			}
+			seen[name] = def.id
+			if be, ok := Parse(name); !ok || be != def.id {
+				t.Fatalf("expected %q to parse as %s", name, def.title)
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package ghec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String returns the ability as written on the card, with its attributes
+// separated by commas.
+func (a Ability) String() string {
+	parts := make([]string, len(a.Attributes))
+	for i, attr := range a.Attributes {
+		parts[i] = attr.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Render returns the text of the ability after applying the base enhancement.
+func Render(a Ability, be BaseEnhancement) (string, error) {
+	enhanced, err := a.Enhance(be)
+	if err != nil {
+		return "", err
+	}
+	return enhanced.String(), nil
+}
+
+// RenderElement returns the text of the ability after infusing the element.
+func RenderElement(a Ability, el Element) (string, error) {
+	enhanced, err := a.EnhanceElement(el)
+	if err != nil {
+		return "", err
+	}
+	return enhanced.String(), nil
+}
+
+// EnhanceElement returns the ability after applying the element enhancement
+// that infuses the element, such as "Infuse Fire".
+func (a Ability) EnhanceElement(el Element) (Ability, error) {
+	if el == ElementNone {
+		return Ability{}, fmt.Errorf("no element to infuse")
+	}
+	return a.enhance(el.BaseEnhancement(), el)
+}
+
+// Enhance returns the ability after applying the base enhancement. A +1
+// raises the number of an attribute the ability has, or adds the attribute
+// with a number of 1. Adding a target or a hex raises the current count. A
+// jump goes right after the move, an element goes last, and anything else
+// goes before the elements and the lost or persistent icons. An element
+// enhancement without a chosen element is written "Infuse Element".
+func (a Ability) Enhance(be BaseEnhancement) (Ability, error) {
+	return a.enhance(be, ElementNone)
+}
+
+// enhance is a helper method that applies the base enhancement with the
+// element it infuses, if any.
+func (a Ability) enhance(be BaseEnhancement, el Element) (Ability, error) {
+	def, ok := lookup(be)
+	if !ok {
+		return Ability{}, fmt.Errorf("unknown base enhancement %d", be)
+	}
+	enhanced := a
+	enhanced.Attributes = append([]Attribute(nil), a.Attributes...)
+	i, found := enhanced.index(be, el)
+	switch {
+	case found && enhanced.Attributes[i].HasValue && numbered(def):
+		enhanced.Attributes[i].Value++
+	case found:
+		return Ability{}, fmt.Errorf("%q already has %s", a, def.title)
+	case be == EnhanceTarget:
+		enhanced.insert(enhanced.tail(), Attribute{Name: def.title, Value: a.Targets + 1, HasValue: true})
+	case be == EnhanceAddAttackHex:
+		enhanced.insert(enhanced.tail(), Attribute{Name: "Area", Value: a.Hexes + 1, HasValue: true})
+	case def.category == CategorySummons:
+		enhanced.insert(enhanced.tail(), Attribute{Name: def.title, Value: 1, HasValue: true, Bonus: true})
+	case numbered(def):
+		enhanced.insert(enhanced.tail(), Attribute{Name: def.title, Value: 1, HasValue: true})
+	case be == EnhanceJump:
+		move, ok := enhanced.index(EnhanceMove, ElementNone)
+		if !ok {
+			move = -1
+		}
+		enhanced.insert(move+1, Attribute{Name: def.title})
+	case def.category == CategoryElement:
+		enhanced.insert(enhanced.markers(), Attribute{Name: elementText(be, el)})
+	default:
+		enhanced.insert(enhanced.tail(), Attribute{Name: def.title})
+	}
+	switch be {
+	case EnhanceTarget:
+		enhanced.Targets++
+	case EnhanceAddAttackHex:
+		enhanced.Hexes++
+	}
+	return enhanced, nil
+}
+
+// index is a helper method that returns the index of the attribute the base
+// enhancement changes.
+func (a Ability) index(be BaseEnhancement, el Element) (int, bool) {
+	for i, attr := range a.Attributes {
+		if be == EnhanceAddAttackHex && isAreaWord(attr.Name) {
+			return i, true
+		}
+		if attr.Is(Title(be)) || attr.Is(elementText(be, el)) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// insert is a helper method that inserts the attribute at the index.
+func (a *Ability) insert(i int, attr Attribute) {
+	a.Attributes = append(a.Attributes[:i], append([]Attribute{attr}, a.Attributes[i:]...)...)
+}
+
+// markers is a helper method that returns the index of the lost or persistent
+// icons at the end of the ability, or its length if it has none.
+func (a Ability) markers() int {
+	i := len(a.Attributes)
+	for i > 0 && (a.Attributes[i-1].Is("lost") || a.Attributes[i-1].Is("persistent")) {
+		i--
+	}
+	return i
+}
+
+// tail is a helper method that returns the index of the elements and icons at
+// the end of the ability, where new attributes go before.
+func (a Ability) tail() int {
+	i := a.markers()
+	for i > 0 && strings.HasPrefix(strings.ToLower(a.Attributes[i-1].Name), "infuse ") {
+		i--
+	}
+	return i
+}
+
+// numbered is a helper function that reports whether the base enhancement
+// raises a number in the ability text.
+func numbered(def definition) bool {
+	switch def.category {
+	case CategoryNumeric, CategorySummons, CategoryArea:
+		return true
+	}
+	switch def.id {
+	case EnhancePush, EnhancePull, EnhanceTeleport:
+		return true
+	}
+	return false
+}
+
+// elementText is a helper function that returns how an element enhancement is
+// written in ability text, with the element if it is chosen.
+func elementText(be BaseEnhancement, el Element) string {
+	if el != ElementNone && Category(be) == CategoryElement {
+		return "Infuse " + el.Title()
+	}
+	switch be {
+	case EnhanceSpecificElement:
+		return "Infuse Element"
+	case EnhanceAnyElement:
+		return "Infuse Any Element"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,74 @@
+package ghec_test
+
+import (
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		text     string
+		base     ghec.BaseEnhancement
+		expected string
+	}{
+		{"Attack 3, Range 2", ghec.EnhanceAttack, "Attack 4, Range 2"},
+		{"Attack 3, Range 2", ghec.EnhancePoison, "Attack 3, Range 2, Poison"},
+		{"Attack 3", ghec.EnhanceTarget, "Attack 3, Target 2"},
+		{"Attack 3, Target 2", ghec.EnhanceTarget, "Attack 3, Target 3"},
+		{"Attack 2, Area 3", ghec.EnhanceAddAttackHex, "Attack 2, Area 4"},
+		{"Attack 2", ghec.EnhanceAddAttackHex, "Attack 2, Area 2"},
+		{"Attack 2, Push 1", ghec.EnhancePush, "Attack 2, Push 2"},
+		{"Attack 3, Teleport 2", ghec.EnhanceTeleport, "Attack 3, Teleport 3"},
+		{"Attack 2", ghec.EnhancePierce, "Attack 2, Pierce 1"},
+		{"Move 3, Loot 1", ghec.EnhanceJump, "Move 3, Jump, Loot 1"},
+		{"Attack 4, Lost", ghec.EnhanceWound, "Attack 4, Wound, Lost"},
+		{"Attack 4, Lost", ghec.EnhanceSpecificElement, "Attack 4, Infuse Element, Lost"},
+		{"Attack 4, Infuse Element", ghec.EnhanceMuddle, "Attack 4, Muddle, Infuse Element"},
+		{"Summon Rat Swarm", ghec.EnhanceSummonsHP, "Summon Rat Swarm, Summons HP +1"},
+		{"Summon Rat Swarm, Summons HP +1", ghec.EnhanceSummonsHP, "Summon Rat Swarm, Summons HP +2"},
+	}
+	for _, tc := range tests {
+		ability, err := ghec.ParseAbility(tc.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ghec.Render(ability, tc.base)
+		if err != nil {
+			t.Fatalf("%q with %s: %v", tc.text, ghec.Title(tc.base), err)
+		}
+		if got != tc.expected {
+			t.Fatalf("%q with %s: expected %q, got %q", tc.text, ghec.Title(tc.base), tc.expected, got)
+		}
+	}
+}
+
+func TestRenderRejectsDuplicates(t *testing.T) {
+	ability, err := ghec.ParseAbility("Move 3, Jump, Poison")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, be := range []ghec.BaseEnhancement{ghec.EnhanceJump, ghec.EnhancePoison} {
+		if _, err := ghec.Render(ability, be); err == nil {
+			t.Fatalf("expected an error adding %s again", ghec.Title(be))
+		}
+	}
+}
+
+func TestEnhanceUpdatesCounts(t *testing.T) {
+	ability, err := ghec.ParseAbility("Attack 2, Area 3, Target 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ability, err = ability.Enhance(ghec.EnhanceAddAttackHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ability, err = ability.Enhance(ghec.EnhanceTarget)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ability.Hexes != 4 || ability.Targets != 3 {
+		t.Fatalf("expected 4 hexes and 3 targets, got %d and %d", ability.Hexes, ability.Targets)
+	}
+}
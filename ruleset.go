@@ -0,0 +1,166 @@
+package ghec
+
+import "fmt"
+
+// Ruleset holds the pricing rules of a game. An enhancement delegates every
+// table lookup to its ruleset, so supporting another game is a matter of
+// implementing this interface.
+type Ruleset interface {
+	// Name is the name of the game, as accepted by RulesetByName.
+	Name() string
+	// Enhancements returns the base enhancements that the game allows.
+	Enhancements() []BaseEnhancement
+	// BaseCost returns the base cost of the base enhancement. For Add Attack
+	// Hex, it returns the whole amount, which AddHexCost divides by the number
+	// of current hexes.
+	BaseCost(be BaseEnhancement) (Cost, error)
+	// LevelCost returns the additional cost for the ability card level.
+	LevelCost(level Level) (Cost, error)
+	// PreviousCost returns the additional cost for the number of previous
+	// enhancements.
+	PreviousCost(pe PreviousEnhancements) (Cost, error)
+	// PreviousScope returns which of an ability card's enhancements count as
+	// previous enhancements.
+	PreviousScope() PreviousScope
+	// DoublesForMultipleTargets reports whether the base cost of the base
+	// enhancement doubles when the ability has multiple targets.
+	DoublesForMultipleTargets(be BaseEnhancement) bool
+	// AddHexCost returns the cost to add a hex to an area of effect with the
+	// given number of current hexes.
+	AddHexCost(hexes int) (Cost, error)
+	// LostActionCost adjusts the base cost when the action is a lost action.
+	LostActionCost(be BaseEnhancement, cost Cost) Cost
+	// PersistentActionCost adjusts the base cost when the action is a
+	// persistent action. It takes precedence over LostActionCost.
+	PersistentActionCost(be BaseEnhancement, cost Cost) Cost
+	// EnhancerDiscount returns the discounts for the Enhancer building level.
+	EnhancerDiscount(el EnhancerLevel) (Discount, error)
+}
+
+// PreviousScope is an enum of which enhancements on an ability card count as
+// previous enhancements.
+type PreviousScope int
+
+// Scope* are constants for the previous enhancement scopes. ScopeCard counts
+// every enhancement on the card, and ScopeAction counts only the enhancements
+// on the same action.
+const (
+	ScopeCard PreviousScope = iota
+	ScopeAction
+)
+
+// String returns the name of the scope.
+func (s PreviousScope) String() string {
+	switch s {
+	case ScopeCard:
+		return "card"
+	case ScopeAction:
+		return "action"
+	default:
+		return "unknown"
+	}
+}
+
+// Rulesets returns all the available rulesets. The first one is the default.
+func Rulesets() []Ruleset {
+	return []Ruleset{
+		Gloomhaven1e{},
+		Frosthaven{},
+		Gloomhaven2e{},
+		JawsOfTheLion{},
+	}
+}
+
+// Allows reports whether the ruleset allows the base enhancement.
+func Allows(r Ruleset, be BaseEnhancement) bool {
+	for _, allowed := range r.Enhancements() {
+		if allowed == be {
+			return true
+		}
+	}
+	return false
+}
+
+// notAvailableError is a helper function that returns the error for a base
+// enhancement the ruleset does not allow.
+func notAvailableError(r Ruleset, be BaseEnhancement) error {
+	return fmt.Errorf("%s is not available in %s", Title(be), r.Name())
+}
+
+// DefaultRuleset returns the ruleset used when none is chosen.
+func DefaultRuleset() Ruleset {
+	return Rulesets()[0]
+}
+
+// RulesetByName returns the ruleset with the given name.
+func RulesetByName(name string) (Ruleset, error) {
+	for _, r := range Rulesets() {
+		if r.Name() == name {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown game %q, must be one of %v", name, RulesetNames())
+}
+
+// RulesetNames returns the names of all the available rulesets.
+func RulesetNames() []string {
+	rulesets := Rulesets()
+	names := make([]string, len(rulesets))
+	for i, r := range rulesets {
+		names[i] = r.Name()
+	}
+	return names
+}
+
+// NextRuleset returns the ruleset after r, wrapping around to the first.
+func NextRuleset(r Ruleset) Ruleset {
+	rulesets := Rulesets()
+	for i, rs := range rulesets {
+		if rs.Name() == r.Name() {
+			return rulesets[(i+1)%len(rulesets)]
+		}
+	}
+	return rulesets[0]
+}
+
+// addHexCost is a helper function that returns the ruleset's Add Attack Hex
+// base cost divided by the number of current hexes, rounded down, as every
+// game divides it.
+func addHexCost(r Ruleset, hexes int) (Cost, error) {
+	if hexes < 1 {
+		return 0, fmt.Errorf("current hexes must be at least 1, not %d", hexes)
+	}
+	cost, err := r.BaseCost(EnhanceAddAttackHex)
+	if err != nil {
+		return 0, err
+	}
+	return cost / Cost(hexes), nil
+}
+
+// costTable maps the base enhancements that a game allows to their base
+// costs.
+type costTable map[BaseEnhancement]Cost
+
+// enhancements returns the base enhancements in the table, in registry order.
+func (t costTable) enhancements() []BaseEnhancement {
+	var list []BaseEnhancement
+	for _, def := range registry {
+		if _, ok := t[def.id]; ok {
+			list = append(list, def.id)
+		}
+	}
+	return list
+}
+
+// baseCost returns the base cost of the base enhancement, or an error if the
+// ruleset does not allow it.
+func (t costTable) baseCost(r Ruleset, be BaseEnhancement) (Cost, error) {
+	if _, ok := lookup(be); !ok {
+		return 0, fmt.Errorf("unknown base enhancement %d", be)
+	}
+	cost, ok := t[be]
+	if !ok {
+		return 0, notAvailableError(r, be)
+	}
+	return cost, nil
+}
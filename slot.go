@@ -0,0 +1,122 @@
+package ghec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SlotType is an enum of the enhancement slots printed on ability cards.
+// Each slot only accepts certain base enhancements.
+type SlotType int
+
+// Slot* are constants for all the slot types, exported for type safety.
+// SlotAny is the zero value, for when the slot is not known, and accepts any
+// base enhancement.
+const (
+	SlotAny SlotType = iota
+	SlotSquare
+	SlotCircle
+	SlotDiamond
+	SlotDiamondPlus
+	SlotHex
+)
+
+// SlotTypes returns all the slot types, starting with SlotAny.
+func SlotTypes() []SlotType {
+	return []SlotType{SlotAny, SlotSquare, SlotCircle, SlotDiamond, SlotDiamondPlus, SlotHex}
+}
+
+// String returns the command-line name of the slot type.
+func (s SlotType) String() string {
+	switch s {
+	case SlotAny:
+		return "any"
+	case SlotSquare:
+		return "square"
+	case SlotCircle:
+		return "circle"
+	case SlotDiamond:
+		return "diamond"
+	case SlotDiamondPlus:
+		return "diamond-plus"
+	case SlotHex:
+		return "hex"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseSlot returns the slot type with the given name. An empty name is
+// SlotAny.
+func ParseSlot(name string) (SlotType, error) {
+	if name == "" {
+		return SlotAny, nil
+	}
+	for _, s := range SlotTypes() {
+		if s.String() == strings.ToLower(name) {
+			return s, nil
+		}
+	}
+	return SlotAny, fmt.Errorf("unknown slot %q, must be one of %v", name, SlotTypes())
+}
+
+// MarshalText encodes the slot type as its name, for data files.
+func (s SlotType) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes the slot type from its name, for data files.
+func (s *SlotType) UnmarshalText(text []byte) error {
+	parsed, err := ParseSlot(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// NextSlot returns the slot type after s, wrapping around to SlotAny.
+func NextSlot(s SlotType) SlotType {
+	return (s + 1) % (SlotHex + 1)
+}
+
+// Accepts reports whether the slot takes the base enhancement, as the
+// registry defines.
+func (s SlotType) Accepts(be BaseEnhancement) bool {
+	if s == SlotAny {
+		return true
+	}
+	def, ok := lookup(be)
+	if !ok {
+		return false
+	}
+	for _, slot := range def.slots {
+		if slot == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepted returns the base enhancements in the list that the slot takes, in
+// the same order.
+func (s SlotType) Accepted(list []BaseEnhancement) []BaseEnhancement {
+	var accepted []BaseEnhancement
+	for _, be := range list {
+		if s.Accepts(be) {
+			accepted = append(accepted, be)
+		}
+	}
+	return accepted
+}
+
+// Slot sets the registry uses for the slots that accept each base
+// enhancement. A +1 fits any slot but a hex, effects need a circle or
+// better, conditions need their own diamond, and Add Attack Hex needs a hex.
+var (
+	plusOneSlots           = []SlotType{SlotSquare, SlotCircle, SlotDiamond, SlotDiamondPlus}
+	effectSlots            = []SlotType{SlotCircle, SlotDiamond, SlotDiamondPlus}
+	negativeConditionSlots = []SlotType{SlotDiamond}
+	positiveConditionSlots = []SlotType{SlotDiamondPlus}
+	hexSlots               = []SlotType{SlotHex}
+)
@@ -0,0 +1,199 @@
+package ghec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is the error a Storage returns for a key it does not hold.
+var ErrNotFound = errors.New("not found")
+
+// Storage keeps ghec's state between runs under keys, such as the ledger of
+// each party, which holds its characters and the state of their cards. Values
+// are stored in their JSON data file form. Every method locks the storage, so
+// two terminals cannot interleave their changes, and writes are atomic, so an
+// interrupted write leaves the previous value.
+type Storage interface {
+	// Load decodes the value under the key into v. It returns ErrNotFound if
+	// there is none.
+	Load(key string, v any) error
+	// Save stores v under the key, replacing any value there.
+	Save(key string, v any) error
+	// Update decodes the value under the key into v, if there is one, calls
+	// f, and stores v unless f fails. It holds the lock throughout, so no
+	// other change can come between the load and the save.
+	Update(key string, v any, f func() error) error
+	// Delete removes the value under the key. It returns ErrNotFound if there
+	// is none.
+	Delete(key string) error
+	// Keys returns the keys with values, sorted.
+	Keys() ([]string, error)
+	// Close releases the storage.
+	Close() error
+}
+
+// Storage* are the names of the storage backends.
+const (
+	StorageYAML = "yaml"
+	StorageJSON = "json"
+	StorageKV   = "kv"
+)
+
+// StorageKinds returns the names of the storage backends.
+func StorageKinds() []string {
+	return []string{StorageYAML, StorageJSON, StorageKV}
+}
+
+// OpenStorage opens the storage backend of the kind in the directory. The
+// YAML and JSON backends keep a file for each key under the directory, which
+// can be edited by hand, and the key/value backend keeps every key in the
+// single file ghec.kv.
+func OpenStorage(kind, dir string) (Storage, error) {
+	switch strings.ToLower(kind) {
+	case StorageYAML, StorageJSON:
+		return NewFileStorage(dir, strings.ToLower(kind))
+	case StorageKV:
+		return NewKVStorage(filepath.Join(dir, "ghec.kv"))
+	default:
+		return nil, fmt.Errorf("unknown storage %q, must be one of %v", kind, StorageKinds())
+	}
+}
+
+// PartyKey returns the key of the party's ledger.
+func PartyKey(party string) string {
+	return "parties/" + party
+}
+
+// Parties returns the names of the parties with ledgers in the storage.
+func Parties(s Storage) ([]string, error) {
+	keys, err := s.Keys()
+	if err != nil {
+		return nil, err
+	}
+	var parties []string
+	for _, key := range keys {
+		if party, ok := strings.CutPrefix(key, "parties/"); ok {
+			parties = append(parties, party)
+		}
+	}
+	return parties, nil
+}
+
+// LoadParty returns the party's ledger from the storage. A party without one
+// has an empty ledger, so its first character can be created.
+func LoadParty(s Storage, party string) (*Ledger, error) {
+	l := NewLedger()
+	if err := s.Load(PartyKey(party), l); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return l, nil
+}
+
+// UpdateParty loads the party's ledger, changes it with f, and saves it
+// unless f fails, all under the storage's lock.
+func UpdateParty(s Storage, party string, f func(*Ledger) error) error {
+	l := NewLedger()
+	return s.Update(PartyKey(party), l, func() error {
+		return f(l)
+	})
+}
+
+// Migrate copies every key from one storage to another, replacing the values
+// there, and returns the keys it copied. It leaves the source unchanged.
+func Migrate(from, to Storage) ([]string, error) {
+	keys, err := from.Keys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		var data json.RawMessage
+		if err := from.Load(key, &data); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if err := to.Save(key, data); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return keys, nil
+}
+
+// checkKey is a helper function that returns an error unless the key is a
+// slash-separated path that stays inside the storage.
+func checkKey(key string) error {
+	if key == "" {
+		return errors.New("a storage key cannot be empty")
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." || strings.HasPrefix(part, ".") || strings.ContainsAny(part, `\:`) {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	return nil
+}
+
+// lockPath is a helper function that locks the named lock file, creating it,
+// and returns the function that unlocks it. An exclusive lock waits for every
+// other lock, and a shared lock only waits for exclusive ones.
+func lockPath(name string, exclusive bool) (func() error, error) {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("locking %s: %w", name, err)
+	}
+	return func() error {
+		return errors.Join(unlockFile(f), f.Close())
+	}, nil
+}
+
+// writeFileAtomic is a helper function that writes the data to a temporary
+// file beside the named file and renames it into place, so readers see the
+// old data or the new, never part of it.
+func writeFileAtomic(name string, data []byte) error {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(name)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp, name)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+// sortedKeys is a helper function that returns the keys of the map, sorted.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
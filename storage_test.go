@@ -0,0 +1,262 @@
+package ghec_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jluckyiv/ghec"
+)
+
+func TestStorageBackends(t *testing.T) {
+	for _, kind := range ghec.StorageKinds() {
+		t.Run(kind, func(t *testing.T) {
+			s, err := ghec.OpenStorage(kind, t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			var missing ghec.Ledger
+			if err := s.Load("parties/default", &missing); !errors.Is(err, ghec.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+			err = ghec.UpdateParty(s, "default", func(l *ghec.Ledger) error {
+				if _, err := l.Create("Grok", "Brute", 100); err != nil {
+					return err
+				}
+				_, err := l.Enhance("Grok", ghec.EnhanceAttack, "Trample")
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ghec.UpdateParty(s, "default", func(l *ghec.Ledger) error {
+				return errors.New("changed my mind")
+			})
+			if err == nil {
+				t.Fatal("expected the update to fail")
+			}
+			l, err := ghec.LoadParty(s, "default")
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := l.Character("Grok")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Gold != 50 || len(l.Events) != 2 {
+				t.Fatalf("expected the first update saved and replayed, got %+v", c)
+			}
+			if err := s.Save("notes", map[string]string{"scenario": "Black Barrow"}); err != nil {
+				t.Fatal(err)
+			}
+			keys, err := s.Keys()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(keys, " ") != "notes parties/default" {
+				t.Fatalf("unexpected keys %v", keys)
+			}
+			if err := s.Delete("notes"); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Delete("notes"); !errors.Is(err, ghec.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+			if parties, err := ghec.Parties(s); err != nil || len(parties) != 1 || parties[0] != "default" {
+				t.Fatalf("expected the default party, got %v, %v", parties, err)
+			}
+			if err := s.Save("../outside", 1); err == nil {
+				t.Fatal("expected an error for a key outside the storage")
+			}
+		})
+	}
+}
+
+func TestLoadPartyMissing(t *testing.T) {
+	for _, kind := range ghec.StorageKinds() {
+		t.Run(kind, func(t *testing.T) {
+			s, err := ghec.OpenStorage(kind, t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			l, err := ghec.LoadParty(s, "default")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(l.Characters) != 0 || len(l.Events) != 0 {
+				t.Fatalf("expected an empty ledger, got %+v", l)
+			}
+		})
+	}
+}
+
+func TestYAMLStorageIsEditable(t *testing.T) {
+	dir := t.TempDir()
+	s, err := ghec.NewFileStorage(dir, ghec.StorageYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := `events:
+  - type: create
+    time: 2024-01-02T03:04:05Z
+    character: Grok
+    class: Brute
+    gold: 80
+  - type: gold
+    time: 2024-01-02T03:05:00Z
+    character: Grok
+    gold: -30
+`
+	if err := os.MkdirAll(filepath.Join(dir, "parties"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "parties", "default.yaml"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := ghec.LoadParty(s, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := l.Character("Grok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Gold != 50 {
+		t.Fatalf("expected 50 gold, got %d", c.Gold)
+	}
+	if err := s.Save(ghec.PartyKey("default"), l); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(filepath.Join(dir, "parties", "default.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(saved), "    character: Grok\n") || strings.Contains(string(saved), "{") {
+		t.Fatalf("expected block-style YAML, got\n%s", saved)
+	}
+}
+
+func TestKVStorageIgnoresTornWrites(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "ghec.kv")
+	s, err := ghec.NewKVStorage(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save("a", "first"); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte{3, 0, 0, 0, 9, 0}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	var got string
+	if err := s.Load("a", &got); err != nil || got != "first" {
+		t.Fatalf("expected the value before the torn write, got %q, %v", got, err)
+	}
+	if err := s.Save("b", "second"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Load("b", &got); err != nil || got != "second" {
+		t.Fatalf("expected a write after the torn one, got %q, %v", got, err)
+	}
+}
+
+func TestKVStorageCompacts(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "ghec.kv")
+	s, err := ghec.NewKVStorage(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := strings.Repeat("x", 1024)
+	for i := 0; i < 200; i++ {
+		if err := s.Save("big", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() > 100<<10 {
+		t.Fatalf("expected the file compacted, got %d bytes", info.Size())
+	}
+	var got string
+	if err := s.Load("big", &got); err != nil || got != value {
+		t.Fatalf("expected the value after compacting, got %v", err)
+	}
+}
+
+func TestStorageUpdatesDoNotInterleave(t *testing.T) {
+	for _, kind := range ghec.StorageKinds() {
+		t.Run(kind, func(t *testing.T) {
+			dir := t.TempDir()
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					// Each goroutine opens its own storage, like a terminal.
+					s, err := ghec.OpenStorage(kind, dir)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					n := 0
+					if err := s.Update("count", &n, func() error { n++; return nil }); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+			s, err := ghec.OpenStorage(kind, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n := 0
+			if err := s.Load("count", &n); err != nil || n != 20 {
+				t.Fatalf("expected 20 updates, got %d, %v", n, err)
+			}
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	from, err := ghec.OpenStorage(ghec.StorageYAML, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := ghec.OpenStorage(ghec.StorageKV, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ghec.UpdateParty(from, "default", func(l *ghec.Ledger) error {
+		_, err := l.Create("Grok", "Brute", 70)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ghec.Migrate(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("expected one key, got %v", keys)
+	}
+	l, err := ghec.LoadParty(to, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, err := l.Character("Grok"); err != nil || c.Gold != 70 {
+		t.Fatalf("expected Grok migrated, got %+v, %v", c, err)
+	}
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jluckyiv/ghec"
+)
+
+var categoryStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("205")).
+	Bold(true).
+	Padding(0, 0, 0, 2)
+
+var detailStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("241")).
+	Padding(0, 0, 0, 4)
+
+// categoryDelegate renders items like the default delegate, but uses the
+// line that would space the items for a header at the start of each category.
+// With an ability, it adds a detail line under the selected item with the
+// ability text after the enhancement, infusing the element for element
+// enhancements.
+type categoryDelegate struct {
+	list.DefaultDelegate
+	ability *ghec.Ability
+	element ghec.Element
+}
+
+func newCategoryDelegate(ability *ghec.Ability, el ghec.Element) categoryDelegate {
+	d := list.NewDefaultDelegate()
+	d.SetSpacing(0)
+	return categoryDelegate{d, ability, el}
+}
+
+// Height returns the item height plus the line for the header, and the
+// detail line if there is an ability.
+func (d categoryDelegate) Height() int {
+	if d.ability != nil {
+		return d.DefaultDelegate.Height() + 2
+	}
+	return d.DefaultDelegate.Height() + 1
+}
+
+func (d categoryDelegate) Render(w io.Writer, m list.Model, index int, li list.Item) {
+	header := ""
+	if startsCategory(m.VisibleItems(), index) {
+		header = categoryStyle.Render(ghec.Category(li.(item).be).String())
+	}
+	fmt.Fprintln(w, header)
+	d.DefaultDelegate.Render(w, m, index, li)
+	if d.ability == nil {
+		return
+	}
+	detail := ""
+	if index == m.Index() {
+		detail = detailStyle.Render("→ " + d.preview(li.(item).be))
+	}
+	fmt.Fprint(w, "\n"+detail)
+}
+
+// preview returns the ability text after the enhancement, or why it cannot be
+// rendered.
+func (d categoryDelegate) preview(be ghec.BaseEnhancement) string {
+	text, err := ghec.Render(*d.ability, be)
+	if d.element != ghec.ElementNone && ghec.Category(be) == ghec.CategoryElement {
+		text, err = ghec.RenderElement(*d.ability, d.element)
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return text
+}
+
+// startsCategory reports whether the item at the index is the first of its
+// category among the visible items.
+func startsCategory(items []list.Item, index int) bool {
+	if index == 0 {
+		return true
+	}
+	previous, ok := items[index-1].(item)
+	current, ok2 := items[index].(item)
+	return ok && ok2 && ghec.Category(previous.be) != ghec.Category(current.be)
+}
@@ -7,12 +7,13 @@ import (
 
 type item struct {
 	be ghec.BaseEnhancement
+	r  ghec.Ruleset
 }
 
-func newItem(be ghec.BaseEnhancement) list.Item {
-	return item{be}
+func newItem(be ghec.BaseEnhancement, r ghec.Ruleset) list.Item {
+	return item{be, r}
 }
 
 func (i item) Title() string       { return ghec.Title(i.be) }
-func (i item) Description() string { return ghec.Description(i.be) }
-func (i item) FilterValue() string { return ghec.Title(i.be) + ghec.Description(i.be) }
+func (i item) Description() string { return ghec.DescriptionFor(i.r, i.be) }
+func (i item) FilterValue() string { return ghec.Title(i.be) + i.Description() }
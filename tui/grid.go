@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jluckyiv/ghec"
+)
+
+// gridRows and gridColumns are the size of the grid editor, in rows of hexes
+// and doubled columns, centered on the caster.
+const (
+	gridRows    = 7
+	gridColumns = 15
+)
+
+var (
+	gridCursorStyle = lipgloss.NewStyle().
+			Reverse(true)
+	gridTargetStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("205")).
+			Bold(true)
+	gridNewStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42")).
+			Bold(true)
+	gridHelpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241"))
+)
+
+var gridKeys = key.NewBinding(
+	key.WithKeys("g"),
+	key.WithHelp("g", "aoe grid"),
+)
+
+var gridMoveKeys = key.NewBinding(
+	key.WithKeys("up", "down", "left", "right", "h", "j", "k", "l"),
+)
+
+var gridToggleKeys = key.NewBinding(
+	key.WithKeys(" ", "x"),
+)
+
+var gridNewKeys = key.NewBinding(
+	key.WithKeys("enter", "+"),
+)
+
+var gridCasterKeys = key.NewBinding(
+	key.WithKeys("@", "c"),
+)
+
+var gridClearKeys = key.NewBinding(
+	key.WithKeys("backspace", "delete"),
+)
+
+// grid is the area of effect editor. The player draws the current area of
+// effect hex by hex and then picks the hex to add.
+type grid struct {
+	// aoe is the area of effect drawn so far.
+	aoe ghec.AreaOfEffect
+	// cursor is the hex under the cursor.
+	cursor ghec.Hex
+	// err is why the last key did nothing, if it did nothing.
+	err error
+}
+
+// newGrid returns a grid with the caster in the middle and the cursor beside
+// it.
+func newGrid() grid {
+	caster := ghec.Hex{}
+	return grid{aoe: ghec.AreaOfEffect{Caster: &caster}, cursor: ghec.Hex{Q: 1}}
+}
+
+// column returns the doubled column of the hex, which lines the rows up.
+func column(h ghec.Hex) int {
+	return 2*h.Q + h.R
+}
+
+// inBounds reports whether the hex is on the grid.
+func inBounds(h ghec.Hex) bool {
+	c := column(h)
+	return h.R >= -gridRows/2 && h.R <= gridRows/2 && c >= -gridColumns/2 && c <= gridColumns/2
+}
+
+// move moves the cursor for the arrow key. Left and right move along the
+// row, and up and down zigzag between the rows so the cursor stays in the same
+// column.
+func (g grid) move(k string) grid {
+	c, r := column(g.cursor), g.cursor.R
+	switch k {
+	case "left", "h":
+		c -= 2
+	case "right", "l":
+		c += 2
+	case "up", "k", "down", "j":
+		if k == "up" || k == "k" {
+			r--
+		} else {
+			r++
+		}
+		if c%2 == 0 {
+			c++
+		} else {
+			c--
+		}
+	}
+	next := ghec.Hex{Q: (c - r) / 2, R: r}
+	if inBounds(next) {
+		g.cursor = next
+	}
+	return g
+}
+
+// toggle adds the hex under the cursor to the targets, or removes it.
+func (g grid) toggle() grid {
+	g.err = nil
+	targets := make([]ghec.Hex, 0, len(g.aoe.Targets)+1)
+	removed := false
+	for _, t := range g.aoe.Targets {
+		if t == g.cursor {
+			removed = true
+			continue
+		}
+		targets = append(targets, t)
+	}
+	if !removed {
+		if g.aoe.Caster != nil && *g.aoe.Caster == g.cursor {
+			g.aoe.Caster = nil
+		}
+		if g.aoe.New != nil && *g.aoe.New == g.cursor {
+			g.aoe.New = nil
+		}
+		targets = append(targets, g.cursor)
+	}
+	g.aoe.Targets = targets
+	return g.checkNew()
+}
+
+// setCaster moves the caster to the hex under the cursor.
+func (g grid) setCaster() grid {
+	g.err = nil
+	if g.aoe.Has(g.cursor) {
+		g = g.toggle()
+	}
+	if g.aoe.New != nil && *g.aoe.New == g.cursor {
+		g.aoe.New = nil
+	}
+	cursor := g.cursor
+	g.aoe.Caster = &cursor
+	return g
+}
+
+// setNew picks the hex under the cursor as the hex to add.
+func (g grid) setNew() grid {
+	g.err = g.aoe.CanAdd(g.cursor)
+	if g.err != nil {
+		return g
+	}
+	cursor := g.cursor
+	g.aoe.New = &cursor
+	return g
+}
+
+// clear removes the hex under the cursor, whatever it is.
+func (g grid) clear() grid {
+	g.err = nil
+	if g.aoe.Has(g.cursor) {
+		return g.toggle()
+	}
+	if g.aoe.Caster != nil && *g.aoe.Caster == g.cursor {
+		g.aoe.Caster = nil
+	}
+	if g.aoe.New != nil && *g.aoe.New == g.cursor {
+		g.aoe.New = nil
+	}
+	return g
+}
+
+// checkNew drops the new hex if the targets no longer reach it.
+func (g grid) checkNew() grid {
+	if g.aoe.New != nil && g.aoe.CanAdd(*g.aoe.New) != nil {
+		g.aoe.New = nil
+	}
+	return g
+}
+
+// hexes returns the number of target hexes, at least 1 so there is always a
+// price.
+func (g grid) hexes() int {
+	return max(g.aoe.Hexes(), 1)
+}
+
+// view renders the grid, with the cursor highlighted and help below.
+func (g grid) view() string {
+	var rows []string
+	for r := -gridRows / 2; r <= gridRows/2; r++ {
+		var b strings.Builder
+		for c := -gridColumns / 2; c <= gridColumns/2; c++ {
+			if (c-r)%2 != 0 {
+				b.WriteString(" ")
+				continue
+			}
+			b.WriteString(g.cell(ghec.Hex{Q: (c - r) / 2, R: r}))
+		}
+		rows = append(rows, b.String())
+	}
+	help := "←↓↑→ move • space target • @ caster • enter new hex • del clear • esc back"
+	lines := []string{strings.Join(rows, "\n"), ""}
+	if g.err != nil {
+		lines = append(lines, g.err.Error())
+	}
+	lines = append(lines, gridHelpStyle.Render(help))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// cell renders the hex as a pattern character.
+func (g grid) cell(h ghec.Hex) string {
+	mark, style := ".", lipgloss.NewStyle()
+	switch {
+	case g.aoe.Caster != nil && *g.aoe.Caster == h:
+		mark = "@"
+	case g.aoe.New != nil && *g.aoe.New == h:
+		mark, style = "+", gridNewStyle
+	case g.aoe.Has(h):
+		mark, style = "X", gridTargetStyle
+	}
+	if h == g.cursor {
+		style = gridCursorStyle
+	}
+	return style.Render(mark)
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -14,6 +15,8 @@ import (
 
 type errMsg error
 
+var errNoSelection = errors.New("no enhancement selected")
+
 type state int
 
 const (
@@ -28,8 +31,15 @@ var (
 	containerStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder(), true).
 			BorderForeground(lipgloss.Color("205"))
+	breakdownStyle = lipgloss.NewStyle().
+			Margin(1, 2)
+	breakdownTitleStyle = lipgloss.NewStyle().
+				Bold(true)
 )
 
+// breakdownWidth is the width of the breakdown pane's contents.
+const breakdownWidth = 48
+
 var escKey = key.NewBinding(
 	key.WithKeys("esc"),
 )
@@ -49,6 +59,51 @@ var targetKeys = key.NewBinding(
 	key.WithHelp("+/-", "cur tgts"),
 )
 
+var hexKeys = key.NewBinding(
+	key.WithKeys(">", ".", "<", ","),
+	key.WithHelp("</>", "cur hexes"),
+)
+
+var lostKeys = key.NewBinding(
+	key.WithKeys("x"),
+	key.WithHelp("x", "lost"),
+)
+
+var persistentKeys = key.NewBinding(
+	key.WithKeys("i"),
+	key.WithHelp("i", "persistent"),
+)
+
+var slotKeys = key.NewBinding(
+	key.WithKeys("s"),
+	key.WithHelp("s", "slot"),
+)
+
+var actionKeys = key.NewBinding(
+	key.WithKeys("a"),
+	key.WithHelp("a", "action"),
+)
+
+var sideKeys = key.NewBinding(
+	key.WithKeys("f"),
+	key.WithHelp("f", "foe/friend"),
+)
+
+var elementKeys = key.NewBinding(
+	key.WithKeys("m"),
+	key.WithHelp("m", "element"),
+)
+
+var enhancerKeys = key.NewBinding(
+	key.WithKeys("e"),
+	key.WithHelp("e", "enhancer lvl"),
+)
+
+var rulesetKeys = key.NewBinding(
+	key.WithKeys("r"),
+	key.WithHelp("r", "game"),
+)
+
 type model struct {
 	err error
 	// list holds a list of items and a delegate for rendering the list.
@@ -61,10 +116,44 @@ type model struct {
 		// enhancement cost.
 		prev ghec.PreviousEnhancements
 		// targets is the current number of targets on the card.
-		// Multiple targets double the enhancement cost and adding a hex applies a
-		// formula based on the number of targets.
+		// Multiple targets double the enhancement cost.
 		targets int
+		// hexes is the current number of hexes in the area of effect.
+		// Adding a hex applies a formula based on the number of hexes.
+		hexes int
+		// lost is whether the action is a lost action, which some games price.
+		lost bool
+		// persistent is whether the action is a persistent action, which some
+		// games price.
+		persistent bool
+		// slot is the slot type on the card. The list hides the base
+		// enhancements that the slot cannot take.
+		slot ghec.SlotType
+		// action is the action type the enhancement goes on. The breakdown
+		// explains why a base enhancement does not suit it.
+		action ghec.ActionType
+		// side is whom the action targets, which decides the conditions it can
+		// take.
+		side ghec.Side
+		// element is the element an element enhancement infuses. Wild goes
+		// with Any Element and the others with Specific Element.
+		element ghec.Element
 	}
+	// ruleset is the game whose tables price the enhancement.
+	// It is not a modifier, so resetting the modifiers keeps it.
+	ruleset ghec.Ruleset
+	// enhancerLevel is the campaign's Enhancer building level, which discounts
+	// the enhancement cost in some games. Like the ruleset, it is not reset.
+	enhancerLevel ghec.EnhancerLevel
+	// grid is the area of effect editor, and editingGrid is whether it is
+	// showing instead of the list. While it shows, it prices Add Attack Hex
+	// from the hexes drawn on it.
+	grid        grid
+	editingGrid bool
+	// ability is the parsed ability text, if any. It sets the modifiers that
+	// resetting returns to, and the list previews the text after each
+	// enhancement.
+	ability *ghec.Ability
 	// state is the current state of the UI.
 	state state
 	// width and height are the current terminal dimensions.
@@ -72,18 +161,31 @@ type model struct {
 	height int
 }
 
-func initialModel() model {
+func initialModel(r ghec.Ruleset, el ghec.EnhancerLevel, ability *ghec.Ability) model {
 	// Set the initial state.
 	state := starting
 	// Set the list items and the data map.
-	items := enhancementsData()
+	items := enhancementsData(r, ghec.SlotAny)
 	// Create the list.Model.
-	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l := list.New(items, newCategoryDelegate(ability, ghec.ElementNone), 0, 0)
+	// The g key opens the grid editor, so only home goes to the start.
+	l.KeyMap.GoToStart.SetKeys("home")
+	l.KeyMap.GoToStart.SetHelp("home", "go to start")
 	l.AdditionalShortHelpKeys = func() []key.Binding {
 		return []key.Binding{
 			levelKeys,
 			previousEnhancementKeys,
 			targetKeys,
+			hexKeys,
+			lostKeys,
+			persistentKeys,
+			slotKeys,
+			actionKeys,
+			sideKeys,
+			elementKeys,
+			gridKeys,
+			enhancerKeys,
+			rulesetKeys,
 		}
 	}
 	l.AdditionalFullHelpKeys = func() []key.Binding {
@@ -91,24 +193,38 @@ func initialModel() model {
 			levelKeys,
 			previousEnhancementKeys,
 			targetKeys,
+			hexKeys,
+			lostKeys,
+			persistentKeys,
+			slotKeys,
+			actionKeys,
+			sideKeys,
+			elementKeys,
+			gridKeys,
+			enhancerKeys,
+			rulesetKeys,
 		}
 	}
 	// Set the model from the data.
-	m := model{state: state, list: l}
+	m := model{state: state, list: l, ruleset: r, enhancerLevel: el, ability: ability}
 	// Set default values for level, targets, and previous enhancements.
 	return m.resetModifiers()
 }
 
-func enhancementsData() []list.Item {
-	// Get a temporary list of the base enhancements.
-	baseEnhancements := ghec.BaseEnhancements()
+func enhancementsData(r ghec.Ruleset, s ghec.SlotType) []list.Item {
+	// Get a temporary list of the base enhancements the ruleset allows and
+	// the slot takes, grouped by category so each group gets a header.
+	var baseEnhancements []ghec.BaseEnhancement
+	for _, c := range ghec.Categories() {
+		baseEnhancements = append(baseEnhancements, ghec.InCategory(c, s.Accepted(r.Enhancements()))...)
+	}
 
 	items := make([]list.Item, len(baseEnhancements))
 
 	// Assign the base enhancements to the list items and the data map
 	// from the same loop.
 	for i, be := range baseEnhancements {
-		item := newItem(be)
+		item := newItem(be, r)
 		items[i] = item
 	}
 	return items
@@ -122,34 +238,136 @@ func (m model) targets() int {
 	return m.modifiers.targets
 }
 
+// hexes returns the number of current hexes, which the grid editor counts
+// while it shows.
+func (m model) hexes() int {
+	if m.editingGrid {
+		return m.grid.hexes()
+	}
+	return m.modifiers.hexes
+}
+
 func (m model) prev() ghec.PreviousEnhancements {
 	return m.modifiers.prev
 }
 
+func (m model) lost() bool {
+	return m.modifiers.lost
+}
+
+func (m model) persistent() bool {
+	return m.modifiers.persistent
+}
+
+func (m model) slot() ghec.SlotType {
+	return m.modifiers.slot
+}
+
+func (m model) action() ghec.ActionType {
+	return m.modifiers.action
+}
+
+func (m model) side() ghec.Side {
+	return m.modifiers.side
+}
+
+func (m model) element() ghec.Element {
+	return m.modifiers.element
+}
+
 func (m model) title() string {
 	title := fmt.Sprintf(
-		"Level: %1d, Targets: %2d, Previous: %1d",
-		m.level(), m.targets(), m.prev(),
+		"Level: %1d, Targets: %2d, Hexes: %2d, Previous: %1d",
+		m.level(), m.targets(), m.hexes(), m.prev(),
 	)
+	if m.lost() {
+		title += ", Lost"
+	}
+	if m.persistent() {
+		title += ", Persistent"
+	}
+	if m.slot() != ghec.SlotAny {
+		title += fmt.Sprintf(", Slot: %s", m.slot())
+	}
+	label := "Cost"
+	if m.editingGrid {
+		label = ghec.Title(ghec.EnhanceAddAttackHex)
+	}
 	cost, err := m.cost()
 	if err != nil {
 		return title
 	}
-	return fmt.Sprintf("%s, Cost: %3d", title, cost)
+	return fmt.Sprintf("%s, %s: %3d", title, label, cost)
 }
 
-func (m model) selectedBaseEnhancement() ghec.BaseEnhancement {
-	selected := m.list.SelectedItem().(item)
-	return selected.be
+// selectedBaseEnhancement returns the base enhancement of the selected item,
+// or Add Attack Hex while the grid editor shows. It returns false when the
+// filter leaves nothing to select.
+func (m model) selectedBaseEnhancement() (ghec.BaseEnhancement, bool) {
+	if m.editingGrid {
+		return ghec.EnhanceAddAttackHex, true
+	}
+	selected, ok := m.list.SelectedItem().(item)
+	return selected.be, ok
 }
 
-func (m model) cost() (ghec.Cost, error) {
-	be := m.selectedBaseEnhancement()
-	return ghec.NewEnhancement(be,
+// options returns the enhancement options for the current modifiers.
+func (m model) options() []ghec.Option {
+	return []ghec.Option{
 		ghec.OptionWithLevel(m.level()),
-		ghec.OptionWithMultipleTarget(m.targets()),
+		ghec.OptionWithTargets(m.targets()),
+		ghec.OptionWithHexes(m.hexes()),
 		ghec.OptionWithPreviousEnhancements(m.prev()),
-	).Cost()
+		ghec.OptionWithRuleset(m.ruleset),
+		ghec.OptionLostAction(m.lost()),
+		ghec.OptionPersistentAction(m.persistent()),
+		ghec.OptionWithEnhancerLevel(m.enhancerLevel),
+		ghec.OptionWithSlot(m.slot()),
+		ghec.OptionWithAction(m.action()),
+		ghec.OptionWithSide(m.side()),
+		ghec.OptionWithElement(m.element()),
+	}
+}
+
+func (m model) cost() (ghec.Cost, error) {
+	be, ok := m.selectedBaseEnhancement()
+	if !ok {
+		return 0, errNoSelection
+	}
+	return ghec.NewEnhancement(be, m.options()...).Cost()
+}
+
+func (m model) breakdown() ([]ghec.LineItem, error) {
+	be, ok := m.selectedBaseEnhancement()
+	if !ok {
+		return nil, errNoSelection
+	}
+	return ghec.NewEnhancement(be, m.options()...).Breakdown()
+}
+
+// breakdownView renders the game and the itemized cost of the selected
+// enhancement.
+func (m model) breakdownView(width int) string {
+	header := breakdownTitleStyle.Render(fmt.Sprintf("Game: %s, Enhancer: %d", m.ruleset.Name(), m.enhancerLevel))
+	if m.action() != ghec.ActionAny || m.side() != ghec.SideAny {
+		header = lipgloss.JoinVertical(lipgloss.Left, header, fmt.Sprintf("Action: %s, Side: %s", m.action(), m.side()))
+	}
+	if m.element() != ghec.ElementNone {
+		header = lipgloss.JoinVertical(lipgloss.Left, header, fmt.Sprintf("Element: %s", m.element()))
+	}
+	items, err := m.breakdown()
+	if err != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, header, "", lipgloss.NewStyle().Width(width).Render(err.Error()))
+	}
+	labelW := width - 6
+	lines := []string{header, ""}
+	for _, item := range items {
+		label := lipgloss.NewStyle().Width(labelW).Render(item.Label)
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Bottom, label, fmt.Sprintf("%+5d", item.Cost)))
+	}
+	total := lipgloss.NewStyle().Width(labelW).Render("total")
+	lines = append(lines, "", lipgloss.JoinHorizontal(lipgloss.Bottom, total, fmt.Sprintf("%5d", ghec.Total(items))))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
 func (m model) Init() tea.Cmd {
@@ -175,16 +393,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.editingGrid {
+			// The grid editor takes every key but the list's force quit.
+			if key.Matches(msg, m.list.KeyMap.ForceQuit) {
+				m.state = quitting
+				return m, tea.Quit
+			}
+			return m.updateGrid(msg), nil
+		}
 		if key.Matches(msg, escKey) && !m.list.IsFiltered() {
 			// If the list is filtered, don't quit the app.
 			// Instead, reset the model and return so the list is not updated.
-			if m.level() == 1 && m.targets() == 1 && m.prev() == 0 {
+			if m.modifiers == m.resetModifiers().modifiers {
 				m.state = quitting
 				return m, tea.Quit
 			}
 			return m.resetModifiers(), nil
 		}
 
+		// While the filter is being typed, keys belong to the filter.
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+
 		if key.Matches(msg, levelKeys) {
 			m = m.setCardLevel(msg)
 		}
@@ -194,6 +425,39 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if key.Matches(msg, targetKeys) {
 			m = m.setCurrentTargets(msg)
 		}
+		if key.Matches(msg, hexKeys) {
+			m = m.setCurrentHexes(msg)
+		}
+		if key.Matches(msg, lostKeys) {
+			m.modifiers.lost = !m.modifiers.lost
+		}
+		if key.Matches(msg, persistentKeys) {
+			m.modifiers.persistent = !m.modifiers.persistent
+		}
+		if key.Matches(msg, slotKeys) {
+			m = m.setSlot(ghec.NextSlot(m.slot()))
+		}
+		if key.Matches(msg, actionKeys) {
+			m.modifiers.action = ghec.NextAction(m.action())
+		}
+		if key.Matches(msg, sideKeys) {
+			// The list pages forward on f, so don't pass the key on.
+			m.modifiers.side = ghec.NextSide(m.side())
+			return m, nil
+		}
+		if key.Matches(msg, elementKeys) {
+			m = m.setElement(ghec.NextElement(m.element()))
+		}
+		if key.Matches(msg, gridKeys) {
+			m.editingGrid = true
+			return m, nil
+		}
+		if key.Matches(msg, enhancerKeys) {
+			m.enhancerLevel = ghec.IncrementEnhancerLevel(m.enhancerLevel)
+		}
+		if key.Matches(msg, rulesetKeys) {
+			return m.setRuleset(ghec.NextRuleset(m.ruleset)), nil
+		}
 	}
 
 	m.list, cmd = m.list.Update(msg)
@@ -201,6 +465,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// updateGrid handles the keys of the grid editor. The level and previous
+// enhancements keys still work, so the price can be checked for other cards.
+// Leaving the editor keeps the hexes it counted.
+func (m model) updateGrid(msg tea.KeyMsg) model {
+	switch {
+	case key.Matches(msg, escKey):
+		m.editingGrid = false
+		m.modifiers.hexes = m.grid.hexes()
+	case key.Matches(msg, gridMoveKeys):
+		m.grid = m.grid.move(msg.String())
+	case key.Matches(msg, gridToggleKeys):
+		m.grid = m.grid.toggle()
+	case key.Matches(msg, gridCasterKeys):
+		m.grid = m.grid.setCaster()
+	case key.Matches(msg, gridNewKeys):
+		m.grid = m.grid.setNew()
+	case key.Matches(msg, gridClearKeys):
+		m.grid = m.grid.clear()
+	case key.Matches(msg, levelKeys):
+		m = m.setCardLevel(msg)
+	case key.Matches(msg, previousEnhancementKeys):
+		m = m.setPreviousEnhancements(msg)
+	}
+	return m
+}
+
 func (m model) setCurrentTargets(msg tea.KeyMsg) model {
 	if msg.String() == "+" || msg.String() == "=" {
 		m.modifiers.targets = m.modifiers.targets + 1
@@ -211,6 +501,16 @@ func (m model) setCurrentTargets(msg tea.KeyMsg) model {
 	return m
 }
 
+func (m model) setCurrentHexes(msg tea.KeyMsg) model {
+	if msg.String() == ">" || msg.String() == "." {
+		m.modifiers.hexes = m.modifiers.hexes + 1
+	}
+	if (msg.String() == "<" || msg.String() == ",") && m.modifiers.hexes > 1 {
+		m.modifiers.hexes = m.modifiers.hexes - 1
+	}
+	return m
+}
+
 func (m model) setPreviousEnhancements(msg tea.KeyMsg) model {
 	if msg.String() == "P" {
 		m.modifiers.prev = ghec.DecrementPrevious(m.modifiers.prev)
@@ -228,10 +528,44 @@ func (m model) setCardLevel(msg tea.KeyMsg) model {
 	return m
 }
 
+func (m model) setRuleset(r ghec.Ruleset) model {
+	m.ruleset = r
+	m.list.SetItems(enhancementsData(r, m.slot()))
+	return m
+}
+
+func (m model) setSlot(s ghec.SlotType) model {
+	m.modifiers.slot = s
+	m.list.SetItems(enhancementsData(m.ruleset, s))
+	return m
+}
+
 func (m model) resetModifiers() model {
 	m.modifiers.level = 1
 	m.modifiers.targets = 1
+	m.modifiers.hexes = 1
 	m.modifiers.prev = 0
+	m.modifiers.lost = false
+	m.modifiers.persistent = false
+	m.modifiers.action = ghec.ActionAny
+	m.modifiers.side = ghec.SideAny
+	m.modifiers.element = ghec.ElementNone
+	m.grid = newGrid()
+	if m.ability != nil {
+		m.modifiers.targets = m.ability.Targets
+		m.modifiers.hexes = m.ability.Hexes
+		m.modifiers.lost = m.ability.Lost
+		m.modifiers.persistent = m.ability.Persistent
+		m.modifiers.action = m.ability.Type
+		m.modifiers.side = m.ability.Side
+	}
+	return m.setElement(ghec.ElementNone).setSlot(ghec.SlotAny)
+}
+
+// setElement sets the element and the list delegate that previews it.
+func (m model) setElement(el ghec.Element) model {
+	m.modifiers.element = el
+	m.list.SetDelegate(newCategoryDelegate(m.ability, el))
 	return m
 }
 
@@ -255,17 +589,32 @@ func (m model) View() string {
 	m.list.SetWidth(listW)
 	m.list.SetHeight(listH)
 
-	// Set the contents of the list.
+	// Set the contents of the list, or the grid editor in its place.
 	m.list.Title = m.title()
 	content := listStyle.Render(m.list.View())
-	return containerStyle.
+	if m.editingGrid {
+		title := m.list.Styles.Title.Render(m.title())
+		content = listStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, "", m.grid.view()))
+	}
+	listPane := containerStyle.
 		Width(containerW).
 		Height(containerH).
 		Render(content)
+
+	// Render the breakdown beside the list, in a container of its own.
+	breakdownW, _ := breakdownStyle.GetFrameSize()
+	breakdown := breakdownStyle.Render(m.breakdownView(breakdownWidth))
+	breakdownPane := containerStyle.
+		Width(breakdownWidth + breakdownW).
+		Height(lipgloss.Height(listPane) - borderH).
+		Render(breakdown)
+	return lipgloss.JoinHorizontal(lipgloss.Top, listPane, breakdownPane)
 }
 
-func Run() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+// Run runs the TUI. The ability is optional ability text to price and
+// preview the enhancements on.
+func Run(r ghec.Ruleset, el ghec.EnhancerLevel, ability *ghec.Ability) {
+	p := tea.NewProgram(initialModel(r, el, ability), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
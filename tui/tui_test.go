@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jluckyiv/ghec"
+)
+
+// press is a helper function that sends the key to the model.
+func press(t *testing.T, m model, k string) model {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return updated.(model)
+}
+
+func TestSideKeyDoesNotPageTheList(t *testing.T) {
+	m := initialModel(ghec.Gloomhaven1e{}, ghec.EnhancerLevel1, nil)
+	m.list.SetSize(40, 10)
+	if m.list.Paginator.TotalPages < 2 {
+		t.Fatalf("expected the list to have several pages, got %d", m.list.Paginator.TotalPages)
+	}
+	m = press(t, m, "f")
+	if m.side() != ghec.NextSide(ghec.SideAny) {
+		t.Fatalf("expected the side to change, got %s", m.side())
+	}
+	if m.list.Paginator.Page != 0 {
+		t.Fatalf("expected the first page, got page %d", m.list.Paginator.Page)
+	}
+}
+
+func TestGridKeyLeavesHomeToTheList(t *testing.T) {
+	m := initialModel(ghec.Gloomhaven1e{}, ghec.EnhancerLevel1, nil)
+	m.list.SetSize(40, 10)
+	m.list.Select(3)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyHome})
+	m = updated.(model)
+	if m.list.Index() != 0 {
+		t.Fatalf("expected home to go to the start, got index %d", m.list.Index())
+	}
+	if m = press(t, m, "g"); !m.editingGrid {
+		t.Fatal("expected g to open the grid editor")
+	}
+}
+
+func TestForceQuitFromTheGrid(t *testing.T) {
+	m := press(t, initialModel(ghec.Gloomhaven1e{}, ghec.EnhancerLevel1, nil), "g")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if cmd == nil {
+		t.Fatal("expected ctrl+c to quit from the grid editor")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("expected ctrl+c to quit from the grid editor")
+	}
+}